@@ -1,6 +1,9 @@
 package pagerduty
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 type Ability string
 
@@ -69,9 +72,26 @@ func (list ListAbilityResponse) GetTotal() uint {
 	return uint(len(list.Abilities))
 }
 
+func (list ListAbilityResponse) GetNextCursor() string {
+	return ""
+}
+
+func (list ListAbilityResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Abilities))
+	for i, a := range list.Abilities {
+		resources[i] = a
+	}
+	return resources
+}
+
 // ListAbilities lists all abilities on your account.
 func (c *Client) ListAbilities() (*ListAbilityResponse, error) {
-	resp, err := c.ListResources(AbilityResourceType)
+	return c.ListAbilitiesWithContext(context.Background())
+}
+
+// ListAbilitiesWithContext is like ListAbilities but takes a context.
+func (c *Client) ListAbilitiesWithContext(ctx context.Context) (*ListAbilityResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, AbilityResourceType)
 	if err != nil {
 		return nil, err
 	}
@@ -81,6 +101,11 @@ func (c *Client) ListAbilities() (*ListAbilityResponse, error) {
 
 // TestAbility Check if your account has the given ability.
 func (c *Client) TestAbility(ability Ability) error {
-	_, err := c.get("/abilities/" + ability.GetID())
+	return c.TestAbilityWithContext(context.Background(), ability)
+}
+
+// TestAbilityWithContext is like TestAbility but takes a context.
+func (c *Client) TestAbilityWithContext(ctx context.Context, ability Ability) error {
+	_, err := c.get(ctx, "/abilities/"+ability.GetID())
 	return err
 }
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -40,9 +41,22 @@ type ListAddonResponse struct {
 	Addons []Addon `json:"addons"`
 }
 
+func (list ListAddonResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Addons))
+	for i, r := range list.Addons {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListAddons lists all of the add-ons installed on your account.
 func (c *Client) ListAddons(opts ...ResourceRequestOptionFunc) (*ListAddonResponse, error) {
-	resp, err := c.ListResources(AddonResourceType, opts...)
+	return c.ListAddonsWithContext(context.Background(), opts...)
+}
+
+// ListAddonsWithContext is like ListAddons but takes a context.
+func (c *Client) ListAddonsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListAddonResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, AddonResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -52,7 +66,12 @@ func (c *Client) ListAddons(opts ...ResourceRequestOptionFunc) (*ListAddonRespon
 
 // GetAddon gets details about an existing add-on.
 func (c *Client) GetAddon(id string) (*Addon, error) {
-	res, err := c.GetResource(AddonResourceType, id)
+	return c.GetAddonWithContext(context.Background(), id)
+}
+
+// GetAddonWithContext is like GetAddon but takes a context.
+func (c *Client) GetAddonWithContext(ctx context.Context, id string) (*Addon, error) {
+	res, err := c.GetResourceWithContext(ctx, AddonResourceType, id)
 	if err != nil {
 	    return nil, err
 	}
@@ -62,7 +81,12 @@ func (c *Client) GetAddon(id string) (*Addon, error) {
 
 // InstallAddon installs an add-on for your account.
 func (c *Client) InstallAddon(a Addon) (*Addon, error) {
-	resp, err := c.CreateResource(a)
+	return c.InstallAddonWithContext(context.Background(), a)
+}
+
+// InstallAddonWithContext is like InstallAddon but takes a context.
+func (c *Client) InstallAddonWithContext(ctx context.Context, a Addon) (*Addon, error) {
+	resp, err := c.CreateResourceWithContext(ctx, a)
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,11 @@
 package pagerduty
 
-import "time"
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
 
 type AlertBody struct {
 	Type     string                 `json:"type"`
@@ -9,7 +14,7 @@ type AlertBody struct {
 }
 
 type Alert struct {
-	APIReference
+	APIObject
 	CreatedAt   time.Time    `json:"created_at"`
 	Status      string       `json:"status"`
 	AlertKey    string       `json:"alert_key"`
@@ -20,3 +25,175 @@ type Alert struct {
 	Severity    string       `json:"severity"`
 	Integration APIReference `json:"integration"`
 }
+
+type AlertResponse struct {
+	APIResponse
+}
+
+func (r AlertResponse) GetResource() (Resource, error) {
+	var dest Alert
+	err := r.getResourceFromResponse(&dest)
+	return dest, err
+}
+
+func NewAlertResponse(resp *http.Response) AlertResponse {
+	return AlertResponse{APIResponse{raw: resp, apiType: AlertResourceType}}
+}
+
+// ListIncidentAlertsResponse is the response structure when calling the
+// ListAlertsForIncident API endpoint.
+type ListIncidentAlertsResponse struct {
+	APIListObject
+	Alerts []Alert `json:"alerts"`
+}
+
+func (list ListIncidentAlertsResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Alerts))
+	for i, r := range list.Alerts {
+		resources[i] = r
+	}
+	return resources
+}
+
+// ListIncidentAlertsOptions is the structure used when passing parameters to
+// the ListAlertsForIncident API endpoint.
+type ListIncidentAlertsOptions struct {
+	APIListObject
+	Statuses []string `url:"statuses,omitempty,brackets"`
+	AlertKey string   `url:"alert_key,omitempty"`
+	SortBy   string   `url:"sort_by,omitempty"`
+	Includes []string `url:"include,omitempty,brackets"`
+}
+
+// ListAlertsForIncident lists the alerts of an incident, optionally filtered
+// with WithOptions(ListIncidentAlertsOptions{...}).
+func (c *Client) ListAlertsForIncident(id string, opts ...ResourceRequestOptionFunc) (*ListIncidentAlertsResponse, error) {
+	return c.ListAlertsForIncidentWithContext(context.Background(), id, opts...)
+}
+
+// ListAlertsForIncidentWithContext is like ListAlertsForIncident but takes a context.
+func (c *Client) ListAlertsForIncidentWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*ListIncidentAlertsResponse, error) {
+	resp, err := c.get(ctx, "/incidents/"+id+"/alerts", opts...)
+	if err != nil {
+		return nil, err
+	}
+	var result ListIncidentAlertsResponse
+	return &result, deserialize(resp, &result)
+}
+
+// AlertIterator iterates over the alerts of an incident.
+type AlertIterator struct {
+	*Iterator
+}
+
+// IncidentAlerts returns an iterator over every alert of an incident matching
+// opts.
+func (c *Client) IncidentAlerts(id string, opts ...ResourceRequestOptionFunc) *AlertIterator {
+	return c.IncidentAlertsWithContext(context.Background(), id, opts...)
+}
+
+// IncidentAlertsWithContext is like IncidentAlerts but takes a context.
+func (c *Client) IncidentAlertsWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) *AlertIterator {
+	return &AlertIterator{newIterator(ctx, func(ctx context.Context, opts ...ResourceRequestOptionFunc) (ResourceList, error) {
+		return c.ListAlertsForIncidentWithContext(ctx, id, opts...)
+	}, opts)}
+}
+
+// Alert returns the alert the iterator is positioned on.
+func (it *AlertIterator) Alert() Alert {
+	return it.Resource().(Alert)
+}
+
+// All returns every remaining alert.
+func (it *AlertIterator) All() ([]Alert, error) {
+	var all []Alert
+	for it.Next() {
+		all = append(all, it.Alert())
+	}
+	return all, it.Err()
+}
+
+// GetAlert gets details about an alert of an incident.
+func (c *Client) GetAlert(incidentID, alertID string) (*Alert, error) {
+	return c.GetAlertWithContext(context.Background(), incidentID, alertID)
+}
+
+// GetAlertWithContext is like GetAlert but takes a context.
+func (c *Client) GetAlertWithContext(ctx context.Context, incidentID, alertID string) (*Alert, error) {
+	resp, err := c.get(ctx, "/incidents/"+incidentID+"/alerts/"+alertID)
+	return getAlertFromResponse(resp, err)
+}
+
+// AlertUpdate is a change to an alert: resolving it by setting its Status to
+// "resolved", or moving it to another incident by setting Incident.
+type AlertUpdate struct {
+	// ID is the ID of the alert. It is only used by ManageAlerts.
+	ID       string        `json:"id,omitempty"`
+	Status   string        `json:"status,omitempty"`
+	Incident *APIReference `json:"incident,omitempty"`
+}
+
+// withDefaults sets the type of the alert and of its incident reference.
+func (u AlertUpdate) withDefaults() interface{} {
+	if u.Incident != nil && u.Incident.Type == "" {
+		incident := *u.Incident
+		incident.Type = "incident_reference"
+		u.Incident = &incident
+	}
+	return struct {
+		Type APIResourceType `json:"type"`
+		AlertUpdate
+	}{AlertResourceType, u}
+}
+
+// UpdateAlert resolves an alert of an incident, or moves it to another
+// incident, on behalf of the user whose email address is from.
+func (c *Client) UpdateAlert(from, incidentID, alertID string, u AlertUpdate) (*Alert, error) {
+	return c.UpdateAlertWithContext(context.Background(), from, incidentID, alertID, u)
+}
+
+// UpdateAlertWithContext is like UpdateAlert but takes a context.
+func (c *Client) UpdateAlertWithContext(ctx context.Context, from, incidentID, alertID string, u AlertUpdate) (*Alert, error) {
+	u.ID = ""
+	data := map[string]interface{}{"alert": u.withDefaults()}
+	resp, err := c.put(ctx, "/incidents/"+incidentID+"/alerts/"+alertID, data, WithHeader("From", from))
+	return getAlertFromResponse(resp, err)
+}
+
+// ManageAlerts resolves or moves several alerts of an incident at once, on
+// behalf of the user whose email address is from. It returns the updated
+// alerts.
+func (c *Client) ManageAlerts(from, incidentID string, updates []AlertUpdate) (*ListIncidentAlertsResponse, error) {
+	return c.ManageAlertsWithContext(context.Background(), from, incidentID, updates)
+}
+
+// ManageAlertsWithContext is like ManageAlerts but takes a context.
+func (c *Client) ManageAlertsWithContext(ctx context.Context, from, incidentID string, updates []AlertUpdate) (*ListIncidentAlertsResponse, error) {
+	alerts := make([]interface{}, len(updates))
+	for i, u := range updates {
+		if u.ID == "" {
+			return nil, &ValidationError{Field: fmt.Sprintf("alerts[%d].id", i), Reason: "is required"}
+		}
+		alerts[i] = u.withDefaults()
+	}
+	data := map[string][]interface{}{"alerts": alerts}
+	resp, err := c.put(ctx, "/incidents/"+incidentID+"/alerts", data, WithHeader("From", from))
+	if err != nil {
+		return nil, err
+	}
+	var result ListIncidentAlertsResponse
+	return &result, deserialize(resp, &result)
+}
+
+func getAlertFromResponse(resp *http.Response, err error) (*Alert, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	res, err := NewAlertResponse(resp).GetResource()
+	if err != nil {
+		return nil, err
+	}
+	obj := res.(Alert)
+	return &obj, nil
+}
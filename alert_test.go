@@ -0,0 +1,81 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIncidentAlerts(t *testing.T) {
+	var queries []string
+	var updates []map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPut {
+			if r.Header.Get("From") != "bot@example.com" {
+				t.Errorf("missing From header on %s", r.URL)
+			}
+			var body map[string]json.RawMessage
+			json.NewDecoder(r.Body).Decode(&body)
+			updates = append(updates, body)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /incidents/PINC1/alerts":
+			queries = append(queries, r.URL.RawQuery)
+			if r.URL.Query().Get("offset") == "" {
+				w.Write([]byte(`{"alerts": [{"id": "PAL1", "type": "alert", "status": "triggered", "alert_key": "disk"}], "limit": 1, "offset": 0, "more": true}`))
+				return
+			}
+			w.Write([]byte(`{"alerts": [{"id": "PAL2", "type": "alert", "status": "triggered", "alert_key": "disk"}], "limit": 1, "offset": 1, "more": false}`))
+		case "GET /incidents/PINC1/alerts/PAL1":
+			w.Write([]byte(`{"alert": {"id": "PAL1", "type": "alert", "status": "triggered", "incident": {"id": "PINC1"}}}`))
+		case "PUT /incidents/PINC1/alerts/PAL1":
+			w.Write([]byte(`{"alert": {"id": "PAL1", "type": "alert", "status": "triggered", "incident": {"id": "PINC2"}}}`))
+		case "PUT /incidents/PINC1/alerts":
+			w.Write([]byte(`{"alerts": [{"id": "PAL2", "type": "alert", "status": "resolved"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	it := client.IncidentAlerts("PINC1", WithOptions(ListIncidentAlertsOptions{Statuses: []string{"triggered"}, AlertKey: "disk"}))
+	it.PageSize = 1
+	alerts, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(alerts) != 2 || alerts[1].ID != "PAL2" {
+		t.Errorf("unexpected alerts %+v", alerts)
+	}
+	if queries[0] != "alert_key=disk&limit=1&statuses%5B%5D=triggered" {
+		t.Errorf("unexpected query %s", queries[0])
+	}
+
+	alert, err := client.GetAlert("PINC1", "PAL1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if alert.GetType() != AlertResourceType || alert.Incident.ID != "PINC1" {
+		t.Errorf("unexpected alert %+v", alert)
+	}
+	moved, err := client.UpdateAlert("bot@example.com", "PINC1", "PAL1", AlertUpdate{Incident: &APIReference{ID: "PINC2"}})
+	if err != nil || moved.Incident.ID != "PINC2" {
+		t.Errorf("unexpected moved alert %+v: %v", moved, err)
+	}
+	if string(updates[0]["alert"]) != `{"type":"alert","incident":{"id":"PINC2","type":"incident_reference"}}` {
+		t.Errorf("unexpected alert update %s", updates[0]["alert"])
+	}
+
+	if _, err := client.ManageAlerts("bot@example.com", "PINC1", []AlertUpdate{{Status: "resolved"}}); err == nil {
+		t.Error("expected an update without an alert id to be rejected")
+	}
+	resolved, err := client.ManageAlerts("bot@example.com", "PINC1", []AlertUpdate{{ID: "PAL2", Status: "resolved"}})
+	if err != nil || len(resolved.Alerts) != 1 || resolved.Alerts[0].Status != "resolved" {
+		t.Errorf("unexpected resolved alerts %+v: %v", resolved, err)
+	}
+	if string(updates[1]["alerts"]) != `[{"type":"alert","id":"PAL2","status":"resolved"}]` {
+		t.Errorf("unexpected alerts update %s", updates[1]["alerts"])
+	}
+}
@@ -20,7 +20,8 @@ type ResourceList interface {
 	GetOffset() uint
 	HasMore() bool
 	GetTotal() uint
-	//GetResources() []Resource
+	GetNextCursor() string
+	GetResources() []Resource
 }
 
 type Response interface {
@@ -37,12 +38,21 @@ var vowels = map[rune]bool{
 	'u': true,
 }
 
+// irregularPlurals are the resource types whose collection name does not follow
+// the rules below.
+var irregularPlurals = map[APIResourceType]APIResourceType{
+	OnCallResourceType: "oncalls",
+}
+
 //IV. Nouns ending in "y"
 //a. If the common noun ends with a consonant + "y" or "qu" + "y" , remove the "y" and add "ies".
 //The vowels are the letters a, e, i, o, and u. All other letters are consonants.
 //b. Common nouns with a vowel + "y", just add "s"
 //Exception: To form the plural of proper nouns ending in "y" preceded by a consonant, just add an "s".
 func (r APIResourceType) Plural() APIResourceType {
+	if plural, ok := irregularPlurals[r]; ok {
+		return plural
+	}
 	l := len(r)
 	if l >= 2 {
 		penultimate, ultimate := r[l-2], r[l-1]
@@ -71,20 +81,23 @@ func (rt apiResourceTypes) Get(typ APIResourceType, resp *http.Response) (Respon
 }
 
 var APIResponses = apiResourceTypes{
-	AbilityResourceType:           func(response *http.Response) Response { return NewAbilityResponse(response) },
-	AddonResourceType:             func(response *http.Response) Response { return NewAddonResponse(response) },
-	EscalationPolicyResourceType:  func(response *http.Response) Response { return NewEscalationPolicyResponse(response) },
-	IncidentResourceType:          func(response *http.Response) Response { return NewIncidentResponse(response) },
-	LogEntryResourceType:          func(response *http.Response) Response { return NewLogEntryResponse(response) },
-	MaintenanceWindowResourceType: func(response *http.Response) Response { return NewMaintenanceWindowResponse(response) },
-	NotificationResourceType:      func(response *http.Response) Response { return NewNotificationResponse(response) },
-	ResponsePlayResourceType:      func(response *http.Response) Response { return NewResponsePlayResponse(response) },
-	ScheduleResourceType:          func(response *http.Response) Response { return NewScheduleResponse(response) },
-	ServiceResourceType:           func(response *http.Response) Response { return NewServiceResponse(response) },
-	TeamResourceType:              func(response *http.Response) Response { return NewTeamResponse(response) },
-	UserResourceType:              func(response *http.Response) Response { return NewUserResponse(response) },
-	VendorResourceType:            func(response *http.Response) Response { return NewVendorResponse(response) },
-	WebhookResourceType:           func(response *http.Response) Response { return NewWebhookResponse(response) },
+	AbilityResourceType:             func(response *http.Response) Response { return NewAbilityResponse(response) },
+	AddonResourceType:               func(response *http.Response) Response { return NewAddonResponse(response) },
+	AlertResourceType:               func(response *http.Response) Response { return NewAlertResponse(response) },
+	EscalationPolicyResourceType:    func(response *http.Response) Response { return NewEscalationPolicyResponse(response) },
+	IncidentResourceType:            func(response *http.Response) Response { return NewIncidentResponse(response) },
+	LogEntryResourceType:            func(response *http.Response) Response { return NewLogEntryResponse(response) },
+	MaintenanceWindowResourceType:   func(response *http.Response) Response { return NewMaintenanceWindowResponse(response) },
+	NotificationResourceType:        func(response *http.Response) Response { return NewNotificationResponse(response) },
+	PriorityResourceType:            func(response *http.Response) Response { return NewPriorityResponse(response) },
+	ResponsePlayResourceType:        func(response *http.Response) Response { return NewResponsePlayResponse(response) },
+	ScheduleResourceType:            func(response *http.Response) Response { return NewScheduleResponse(response) },
+	ServiceResourceType:             func(response *http.Response) Response { return NewServiceResponse(response) },
+	TeamResourceType:                func(response *http.Response) Response { return NewTeamResponse(response) },
+	UserResourceType:                func(response *http.Response) Response { return NewUserResponse(response) },
+	VendorResourceType:              func(response *http.Response) Response { return NewVendorResponse(response) },
+	WebhookResourceType:             func(response *http.Response) Response { return NewWebhookResponse(response) },
+	WebhookSubscriptionResourceType: func(response *http.Response) Response { return NewWebhookSubscriptionResponse(response) },
 }
 
 type ListResponseTypeFunc func(response *http.Response) ResourceList
@@ -99,20 +112,23 @@ func (rt apiListResourceTypes) Get(typ APIResourceType, resp *http.Response) (Re
 }
 
 var APIListResponses = apiListResourceTypes{
-	AbilityResourceType:           func(response *http.Response) ResourceList { return new(ListAbilityResponse) },
-	AddonResourceType:             func(response *http.Response) ResourceList { return new(ListAddonResponse) },
-	EscalationPolicyResourceType:  func(response *http.Response) ResourceList { return new(ListEscalationPoliciesResponse) },
-	IncidentResourceType:          func(response *http.Response) ResourceList { return new(ListIncidentsResponse) },
-	LogEntryResourceType:          func(response *http.Response) ResourceList { return new(ListLogEntryResponse) },
-	MaintenanceWindowResourceType: func(response *http.Response) ResourceList { return new(ListMaintenanceWindowsResponse) },
-	NotificationResourceType:      func(response *http.Response) ResourceList { return new(ListNotificationsResponse) },
-	ResponsePlayResourceType:      func(response *http.Response) ResourceList { return new(ListResponsePlaysResponse) },
-	ScheduleResourceType:          func(response *http.Response) ResourceList { return new(ListSchedulesResponse) },
-	ServiceResourceType:           func(response *http.Response) ResourceList { return new(ListServiceResponse) },
-	TeamResourceType:              func(response *http.Response) ResourceList { return new(ListTeamResponse) },
-	UserResourceType:              func(response *http.Response) ResourceList { return new(ListUsersResponse) },
-	VendorResourceType:            func(response *http.Response) ResourceList { return new(ListVendorResponse) },
-	WebhookResourceType:           func(response *http.Response) ResourceList { return new(ListWebhooksResponse) },
+	AbilityResourceType:             func(response *http.Response) ResourceList { return new(ListAbilityResponse) },
+	AddonResourceType:               func(response *http.Response) ResourceList { return new(ListAddonResponse) },
+	EscalationPolicyResourceType:    func(response *http.Response) ResourceList { return new(ListEscalationPoliciesResponse) },
+	IncidentResourceType:            func(response *http.Response) ResourceList { return new(ListIncidentsResponse) },
+	LogEntryResourceType:            func(response *http.Response) ResourceList { return new(ListLogEntryResponse) },
+	MaintenanceWindowResourceType:   func(response *http.Response) ResourceList { return new(ListMaintenanceWindowsResponse) },
+	NotificationResourceType:        func(response *http.Response) ResourceList { return new(ListNotificationsResponse) },
+	OnCallResourceType:              func(response *http.Response) ResourceList { return new(ListOnCallsResponse) },
+	PriorityResourceType:            func(response *http.Response) ResourceList { return new(ListPrioritiesResponse) },
+	ResponsePlayResourceType:        func(response *http.Response) ResourceList { return new(ListResponsePlaysResponse) },
+	ScheduleResourceType:            func(response *http.Response) ResourceList { return new(ListSchedulesResponse) },
+	ServiceResourceType:             func(response *http.Response) ResourceList { return new(ListServiceResponse) },
+	TeamResourceType:                func(response *http.Response) ResourceList { return new(ListTeamResponse) },
+	UserResourceType:                func(response *http.Response) ResourceList { return new(ListUsersResponse) },
+	VendorResourceType:              func(response *http.Response) ResourceList { return new(ListVendorResponse) },
+	WebhookResourceType:             func(response *http.Response) ResourceList { return new(ListWebhooksResponse) },
+	WebhookSubscriptionResourceType: func(response *http.Response) ResourceList { return new(ListWebhookSubscriptionsResponse) },
 }
 
 type APIResponse struct {
@@ -174,11 +190,13 @@ func (apiObj APIObject) String() string {
 }
 
 // APIListObject are the fields used to control pagination when listing objects.
+// Endpoints using cursor-based pagination return NextCursor instead of More.
 type APIListObject struct {
-	Limit  uint `url:"limit,omitempty" json:"limit"`
-	Offset uint `url:"offset,omitempty" json:"offset"`
-	More   bool `url:"more,omitempty" json:"more"`
-	Total  uint `url:"total,omitempty" json:"total"`
+	Limit      uint   `url:"limit,omitempty" json:"limit"`
+	Offset     uint   `url:"offset,omitempty" json:"offset"`
+	More       bool   `url:"more,omitempty" json:"more"`
+	Total      uint   `url:"total,omitempty" json:"total"`
+	NextCursor string `url:"-" json:"next_cursor,omitempty"`
 }
 
 func (list APIListObject) GetLimit() uint {
@@ -197,6 +215,10 @@ func (list APIListObject) GetTotal() uint {
 	return list.Total
 }
 
+func (list APIListObject) GetNextCursor() string {
+	return list.NextCursor
+}
+
 // APIReference are the fields required to reference another API object.
 type APIReference struct {
 	ID   string `json:"id,omitempty"`
@@ -0,0 +1,80 @@
+package pagerduty
+
+import (
+	"context"
+	"unicode/utf8"
+)
+
+const changeEventPath = "/v2/change/enqueue"
+
+// ChangeEvent reports a change, such as a deploy, to a service. Change events
+// are shown next to the incidents of the service but never create one.
+type ChangeEvent struct {
+	// RoutingKey is the integration key of the service the change is for.
+	RoutingKey string             `json:"routing_key"`
+	Payload    ChangeEventPayload `json:"payload"`
+	Links      []EventLink        `json:"links,omitempty"`
+}
+
+// ChangeEventPayload describes a change.
+type ChangeEventPayload struct {
+	// Summary is a brief text summary of the change, at most 1024 characters.
+	Summary string `json:"summary"`
+	// Source is the unique location of the changed system.
+	Source string `json:"source,omitempty"`
+	// Timestamp is the time the change happened, in RFC 3339 format.
+	Timestamp string      `json:"timestamp,omitempty"`
+	Details   interface{} `json:"custom_details,omitempty"`
+}
+
+// ChangeEventResponse is the data returned from the Change Events API.
+type ChangeEventResponse struct {
+	Status  string `json:"status"`
+	Message string `json:"message"`
+}
+
+// Validate checks that e has every required field. It returns a
+// *ValidationError describing the first problem found.
+func (e ChangeEvent) Validate() error {
+	switch {
+	case e.RoutingKey == "":
+		return &ValidationError{Field: "routing_key", Reason: "is required"}
+	case e.Payload.Summary == "":
+		return &ValidationError{Field: "payload.summary", Reason: "is required"}
+	case utf8.RuneCountInString(e.Payload.Summary) > maxSummaryLength:
+		return &ValidationError{Field: "payload.summary", Reason: "is longer than 1024 characters"}
+	}
+	if err := validateTimestamp("payload.timestamp", e.Payload.Timestamp); err != nil {
+		return err
+	}
+	return validateLinks(e.Links)
+}
+
+// CreateChangeEvent sends a change event, using the default HTTP client.
+func CreateChangeEvent(e ChangeEvent) (*ChangeEventResponse, error) {
+	return CreateChangeEventWithContext(context.Background(), e)
+}
+
+// CreateChangeEventWithContext is like CreateChangeEvent but takes a context.
+func CreateChangeEventWithContext(ctx context.Context, e ChangeEvent) (*ChangeEventResponse, error) {
+	return NewClient("").CreateChangeEventWithContext(ctx, e)
+}
+
+// CreateChangeEvent sends a change event through the client's HTTP client,
+// retry policy and events rate limiter. Like ManageEvent, it validates the
+// event first and returns an *APIError when it is rejected.
+func (c *Client) CreateChangeEvent(e ChangeEvent) (*ChangeEventResponse, error) {
+	return c.CreateChangeEventWithContext(context.Background(), e)
+}
+
+// CreateChangeEventWithContext is like CreateChangeEvent but takes a context.
+func (c *Client) CreateChangeEventWithContext(ctx context.Context, e ChangeEvent) (*ChangeEventResponse, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	var eventResponse ChangeEventResponse
+	if err := c.enqueueEvent(ctx, changeEventPath, e, &eventResponse); err != nil {
+		return nil, err
+	}
+	return &eventResponse, nil
+}
@@ -0,0 +1,57 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestCreateChangeEvent(t *testing.T) {
+	var received map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/change/enqueue" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status": "success", "message": "Change event processed"}`))
+	}))
+	defer server.Close()
+	client := NewClient("", WithCustomClient(rewriteHostClient(server.URL)))
+
+	resp, err := client.CreateChangeEvent(ChangeEvent{
+		RoutingKey: "key",
+		Payload: ChangeEventPayload{
+			Summary:   "Deployed checkout v42",
+			Source:    "ci",
+			Timestamp: "2020-07-17T08:42:58Z",
+			Details:   map[string]string{"build": "42"},
+		},
+		Links: []EventLink{{Href: "https://ci.example.com/builds/42"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.Status != "success" {
+		t.Errorf("unexpected response %+v", resp)
+	}
+	payload, _ := received["payload"].(map[string]interface{})
+	if payload["summary"] != "Deployed checkout v42" || payload["custom_details"] == nil {
+		t.Errorf("unexpected change event sent %v", received)
+	}
+}
+
+func TestChangeEventValidate(t *testing.T) {
+	client := NewClient("", WithCustomClient(blockingHTTPClient{}))
+	_, err := client.CreateChangeEvent(ChangeEvent{RoutingKey: "key"})
+	var verr *ValidationError
+	if !errors.As(err, &verr) || verr.Field != "payload.summary" {
+		t.Errorf("expected a missing summary error, got %v", err)
+	}
+	if err := (ChangeEvent{RoutingKey: "key", Payload: ChangeEventPayload{Summary: strings.Repeat("é", 1024)}}).Validate(); err != nil {
+		t.Errorf("expected a 1024 character summary to be valid, got %v", err)
+	}
+}
@@ -2,6 +2,7 @@ package pagerduty
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
@@ -10,8 +11,6 @@ import (
 	"net/http"
 	"runtime"
 	"time"
-
-	"github.com/pkg/errors"
 )
 
 func newDefaultHTTPClient() *http.Client {
@@ -49,35 +48,51 @@ var defaultHTTPClient HTTPClient = newDefaultHTTPClient()
 
 // Client wraps http client
 type Client struct {
-	authToken   string
-	apiEndpoint string
+	authToken      string
+	apiEndpoint    string
+	eventsEndpoint string
 	// HTTPClient is the HTTP client used for making requests against the
 	// PagerDuty API. You can use either *http.Client here, or your own
 	// implementation.
 	HTTPClient HTTPClient
+
+	retryPolicy       RetryPolicy
+	rateLimiter       RateLimiter
+	eventsRateLimiter RateLimiter
+	middleware        []Middleware
+	logger            Logger
+	logBodies         bool
+	instrumentation   Instrumentation
 }
 
 // DeleteResource deletes the given Resource. The given Resource should return a valid API URL from GetSelf()
 func (c *Client) DeleteResource(typ APIResourceType, id string) error {
+	return c.DeleteResourceWithContext(context.Background(), typ, id)
+}
+
+// DeleteResourceWithContext is like DeleteResource but takes a context.
+func (c *Client) DeleteResourceWithContext(ctx context.Context, typ APIResourceType, id string) error {
 	path := fmt.Sprintf("/%s/%s", typ.Plural(), id)
-	res, err := c.delete(path)
+	res, err := c.delete(ctx, path)
 	if err != nil {
 		return err
 	}
+	defer res.Body.Close()
 	if res.StatusCode != http.StatusNoContent {
-		body, err := ioutil.ReadAll(res.Body)
-		if err != nil {
-			return err
-		}
-		return errors.Errorf("received non-success response %d: %s", res.StatusCode, body)
+		return newAPIError(res.Request, res)
 	}
 	return nil
 }
 
 // GetResource fetches a Resource for a given type and ID
 func (c *Client) GetResource(typ APIResourceType, id string, opts ...ResourceRequestOptionFunc) (Resource, error) {
+	return c.GetResourceWithContext(context.Background(), typ, id, opts...)
+}
+
+// GetResourceWithContext is like GetResource but takes a context.
+func (c *Client) GetResourceWithContext(ctx context.Context, typ APIResourceType, id string, opts ...ResourceRequestOptionFunc) (Resource, error) {
 	path := fmt.Sprintf("/%s/%s", typ.Plural(), id)
-	res, err := c.get(path, opts...)
+	res, err := c.get(ctx, path, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -91,8 +106,13 @@ func (c *Client) GetResource(typ APIResourceType, id string, opts ...ResourceReq
 }
 
 func (c *Client) CreateResource(resource Resource) (Resource, error) {
+	return c.CreateResourceWithContext(context.Background(), resource)
+}
+
+// CreateResourceWithContext is like CreateResource but takes a context.
+func (c *Client) CreateResourceWithContext(ctx context.Context, resource Resource) (Resource, error) {
 	path := fmt.Sprintf("/%s", resource.GetType().Plural())
-	res, err := c.post(path, map[APIResourceType]Resource{resource.GetType(): resource})
+	res, err := c.post(ctx, path, map[APIResourceType]Resource{resource.GetType(): resource})
 	if err != nil {
 		return nil, err
 	}
@@ -104,8 +124,13 @@ func (c *Client) CreateResource(resource Resource) (Resource, error) {
 }
 
 func (c *Client) ListResources(typ APIResourceType, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
+	return c.ListResourcesWithContext(context.Background(), typ, opts...)
+}
+
+// ListResourcesWithContext is like ListResources but takes a context.
+func (c *Client) ListResourcesWithContext(ctx context.Context, typ APIResourceType, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
 	path := fmt.Sprintf("/%s", typ.Plural())
-	res, err := c.get(path, opts...)
+	res, err := c.get(ctx, path, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -113,8 +138,13 @@ func (c *Client) ListResources(typ APIResourceType, opts ...ResourceRequestOptio
 }
 
 func (c *Client) UpdateResource(resource Resource) (Resource, error) {
+	return c.UpdateResourceWithContext(context.Background(), resource)
+}
+
+// UpdateResourceWithContext is like UpdateResource but takes a context.
+func (c *Client) UpdateResourceWithContext(ctx context.Context, resource Resource) (Resource, error) {
 	path := fmt.Sprintf("/%s/%s", resource.GetType().Plural(), resource.GetID())
-	res, err := c.put(path, map[APIResourceType]Resource{resource.GetType(): resource})
+	res, err := c.put(ctx, path, map[APIResourceType]Resource{resource.GetType(): resource})
 	if err != nil {
 		return nil, err
 	}
@@ -130,9 +160,9 @@ func (c *Client) setDefaultHeaders(req *http.Request) {
 	req.Header.Set("Authorization", "Token token="+c.authToken)
 }
 
-func (c *Client) do(method, path string, body io.Reader, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
+func (c *Client) do(ctx context.Context, method, path string, body io.Reader, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
 	endpoint := c.apiEndpoint + path
-	req, err := http.NewRequest(method, endpoint, body)
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
 	if err != nil {
 		return nil, err
 	}
@@ -145,54 +175,47 @@ func (c *Client) do(method, path string, body io.Reader, opts ...ResourceRequest
 	}
 	c.setDefaultHeaders(req)
 
-	resp, err := c.HTTPClient.Do(req)
-	return c.checkResponse(resp, err)
+	resp, err := c.execute(req, resolveRequestInfo(method, path), c.rateLimiter)
+	return c.checkResponse(req, resp, err)
 }
 
-func (c *Client) put(path string, payload interface{}, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
-	return c.write(http.MethodPut, path, payload, opts...)
+func (c *Client) put(ctx context.Context, path string, payload interface{}, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
+	return c.write(ctx, http.MethodPut, path, payload, opts...)
 }
 
-func (c *Client) post(path string, payload interface{}, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
-	return c.write(http.MethodPost, path, payload, opts...)
+func (c *Client) post(ctx context.Context, path string, payload interface{}, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
+	return c.write(ctx, http.MethodPost, path, payload, opts...)
 }
 
-func (c *Client) write(method, path string, payload interface{}, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
+func (c *Client) write(ctx context.Context, method, path string, payload interface{}, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
 	data, err := serialize(payload)
 	if err != nil {
 		return nil, err
 	}
-	return c.do(method, path, data, opts...)
+	if data == nil {
+		return c.do(ctx, method, path, nil, opts...)
+	}
+	return c.do(ctx, method, path, data, opts...)
 }
 
-func (c *Client) delete(path string) (*http.Response, error) {
-	return c.do(http.MethodDelete, path, nil)
+func (c *Client) delete(ctx context.Context, path string) (*http.Response, error) {
+	return c.do(ctx, http.MethodDelete, path, nil)
 }
 
-func (c *Client) get(path string, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
-	return c.do(http.MethodGet, path, nil, opts...)
+func (c *Client) get(ctx context.Context, path string, opts ...ResourceRequestOptionFunc) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, path, nil, opts...)
 }
 
-func (c *Client) checkResponse(resp *http.Response, err error) (*http.Response, error) {
+func (c *Client) checkResponse(req *http.Request, resp *http.Response, err error) (*http.Response, error) {
 	if err != nil {
-		return resp, fmt.Errorf("error calling the API endpoint: %v", err)
+		return resp, fmt.Errorf("error calling the API endpoint: %w", err)
 	}
 	if resp.StatusCode <= 199 || resp.StatusCode >= http.StatusMultipleChoices {
-		err = c.getErrorFromResponse(resp)
-		return resp, fmt.Errorf("non-success HTTP response %v: %s", resp.StatusCode, err.Error())
+		return resp, newAPIError(req, resp)
 	}
 	return resp, nil
 }
 
-func (c *Client) getErrorFromResponse(resp *http.Response) error {
-	var result ErrorResponse
-	err := deserialize(resp, &result)
-	if err != nil {
-		return err
-	}
-	return &result.Error
-}
-
 type NewClientOptionFunc func(*Client)
 
 func WithCustomClient(c HTTPClient) NewClientOptionFunc {
@@ -207,12 +230,44 @@ func WithCustomHost(host string) NewClientOptionFunc {
 	}
 }
 
+// WithEventsEndpoint sends events to the events API at host instead of
+// https://events.pagerduty.com, for instance through a proxy.
+func WithEventsEndpoint(host string) NewClientOptionFunc {
+	return func(client *Client) {
+		client.eventsEndpoint = host
+	}
+}
+
+// Region is a PagerDuty service region, with its own REST and events API
+// hosts.
+type Region struct {
+	APIEndpoint    string
+	EventsEndpoint string
+}
+
+// PagerDuty service regions.
+var (
+	RegionUS = Region{APIEndpoint: apiEndpoint, EventsEndpoint: eventsEndpoint}
+	RegionEU = Region{APIEndpoint: "https://api.eu.pagerduty.com", EventsEndpoint: "https://events.eu.pagerduty.com"}
+)
+
+// WithRegion sends REST and events API calls to the hosts of r. Options given
+// after it, such as WithCustomHost, override its hosts.
+func WithRegion(r Region) NewClientOptionFunc {
+	return func(client *Client) {
+		client.apiEndpoint = r.APIEndpoint
+		client.eventsEndpoint = r.EventsEndpoint
+	}
+}
+
 // NewClient creates an API client
 func NewClient(authToken string, opts ...NewClientOptionFunc) *Client {
 	c := &Client{
-		authToken:   authToken,
-		apiEndpoint: apiEndpoint,
-		HTTPClient:  defaultHTTPClient,
+		authToken:       authToken,
+		apiEndpoint:     apiEndpoint,
+		eventsEndpoint:  eventsEndpoint,
+		HTTPClient:      defaultHTTPClient,
+		instrumentation: NoopInstrumentation{},
 	}
 	for _, opt := range opts {
 		opt(c)
@@ -243,3 +298,20 @@ func deserialize(resp *http.Response, dest interface{}) error {
 	}
 	return nil
 }
+
+// deserializeField decodes the given field of a JSON response object into
+// dest.
+func deserializeField(resp *http.Response, field string, dest interface{}) error {
+	var result map[string]json.RawMessage
+	if err := deserialize(resp, &result); err != nil {
+		return err
+	}
+	raw, ok := result[field]
+	if !ok {
+		return fmt.Errorf("JSON response does not have %s field", field)
+	}
+	if err := json.Unmarshal(raw, dest); err != nil {
+		return fmt.Errorf("could not decode JSON response: %v", err)
+	}
+	return nil
+}
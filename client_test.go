@@ -0,0 +1,114 @@
+package pagerduty
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+type blockingHTTPClient struct{}
+
+func (c blockingHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	<-request.Context().Done()
+	return nil, request.Context().Err()
+}
+
+func TestCancelAbortsRequest(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := NewClient("123", WithCustomHost(server.URL))
+	ctx, cancel := context.WithCancel(context.Background())
+	errs := make(chan error, 1)
+	go func() {
+		_, err := client.ListIncidentsWithContext(ctx)
+		errs <- err
+	}()
+	cancel()
+
+	select {
+	case err := <-errs:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("expected context.Canceled, got %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("request was not aborted by cancelling the context")
+	}
+}
+
+func TestDeadlineAbortsRequest(t *testing.T) {
+	client := NewClient("123", WithCustomClient(blockingHTTPClient{}))
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err := client.GetUserWithContext(ctx, "PXXXXXX")
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestContextReachesRequestOptions(t *testing.T) {
+	type key struct{}
+	ctx := context.WithValue(context.Background(), key{}, "value")
+	var seen interface{}
+	opt := func(request *http.Request) error {
+		seen = request.Context().Value(key{})
+		return nil
+	}
+	client := NewClient("123", WithCustomClient(newTestClient("")))
+	if _, err := client.ListUsersWithContext(ctx, opt); err != nil {
+		t.Fatal(err)
+	}
+	if seen != "value" {
+		t.Errorf("request option did not see the caller's context")
+	}
+}
+
+func TestCancelAbortsEvent(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := CreateEventWithHTTPClientAndContext(ctx, Event{}, blockingHTTPClient{})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("expected context.Canceled, got %v", err)
+	}
+}
+
+func TestEventsEndpointAndRegion(t *testing.T) {
+	var hosts []string
+	client := NewClient("123",
+		WithRegion(RegionEU),
+		WithCustomClient(DoerFunc(func(req *http.Request) (*http.Response, error) {
+			hosts = append(hosts, req.URL.Host)
+			return statusHTTPClient{status: http.StatusOK, body: `{}`}.Do(req)
+		})))
+	client.ListUsers()
+	client.CreateEvent(Event{Type: "trigger"})
+	if len(hosts) != 2 || hosts[0] != "api.eu.pagerduty.com" || hosts[1] != "events.eu.pagerduty.com" {
+		t.Errorf("unexpected hosts %v", hosts)
+	}
+
+	var path string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path = r.URL.Path
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status": "success"}`))
+	}))
+	defer server.Close()
+	client = NewClient("123", WithEventsEndpoint(server.URL))
+	if _, err := client.ManageEvent(resolveEvent("disk")); err != nil {
+		t.Fatal(err)
+	}
+	if path != "/v2/enqueue" {
+		t.Errorf("unexpected path %s", path)
+	}
+}
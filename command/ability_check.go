@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"github.com/kylie-a/go-pagerduty"
 	"github.com/mitchellh/cli"
 	"strings"
 )
@@ -44,7 +45,7 @@ func (c *AbilityTest) Run(args []string) int {
 		return -1
 	}
 	client := c.Meta.Client()
-	if err := client.TestAbility(flags.Arg(0)); err != nil {
+	if err := client.TestAbility(pagerduty.Ability(flags.Arg(0))); err != nil {
 		fmt.Println(err)
 		return -1
 	}
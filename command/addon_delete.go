@@ -2,6 +2,7 @@ package main
 
 import (
 	"fmt"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
@@ -42,7 +43,7 @@ func (c *AddonDelete) Run(args []string) int {
 		log.Error("Please specify addon id")
 		return -1
 	}
-	err := client.DeleteAddon(flags.Arg(0))
+	err := client.DeleteResource(pagerduty.AddonResourceType, flags.Arg(0))
 	if err != nil {
 		log.Error(err)
 		return -1
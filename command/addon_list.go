@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"gopkg.in/yaml.v2"
@@ -58,7 +58,7 @@ func (c *AddonList) Run(args []string) int {
 		ServiceIDs: serviceIDs,
 		Filter:     filter,
 	}
-	if addonList, err := client.ListAddons(opts); err != nil {
+	if addonList, err := client.ListAddons(pagerduty.WithOptions(opts)); err != nil {
 		log.Error(err)
 		return -1
 	} else {
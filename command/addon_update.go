@@ -3,7 +3,7 @@ package main
 import (
 	"encoding/json"
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
@@ -66,7 +66,8 @@ func (c *AddonUpdate) Run(args []string) int {
 		log.Error(err)
 		return -1
 	}
-	if _, err := client.UpdateAddon(newAddon.ID, newAddon); err != nil {
+	newAddon.Type = pagerduty.AddonResourceType
+	if _, err := client.UpdateResource(newAddon); err != nil {
 		log.Error(err)
 		return -1
 	}
@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"gopkg.in/yaml.v2"
@@ -68,7 +68,7 @@ func (c *EscalationPolicyList) Run(args []string) int {
 		Includes: includes,
 		SortBy:   sortBy,
 	}
-	if eps, err := client.ListEscalationPolicies(opts); err != nil {
+	if eps, err := client.ListEscalationPolicies(pagerduty.WithOptions(opts)); err != nil {
 		log.Error(err)
 		return -1
 	} else {
@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"gopkg.in/yaml.v2"
@@ -57,7 +57,7 @@ func (c *EscalationPolicyShow) Run(args []string) int {
 	o := &pagerduty.GetEscalationPolicyOptions{
 		Includes: includes,
 	}
-	ep, err := client.GetEscalationPolicy(epID, o)
+	ep, err := client.GetEscalationPolicy(epID, pagerduty.WithOptions(o))
 	if err != nil {
 		log.Error(err)
 		return -1
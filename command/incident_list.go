@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"gopkg.in/yaml.v2"
@@ -56,7 +56,7 @@ func (c *IncidentList) Run(args []string) int {
 		SortBy:   sortBy,
 		Includes: includes,
 	}
-	if incidentList, err := client.ListIncidents(opts); err != nil {
+	if incidentList, err := client.ListIncidents(pagerduty.WithOptions(opts)); err != nil {
 		log.Error(err)
 		return -1
 	} else {
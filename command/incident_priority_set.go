@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kylie-a/go-pagerduty"
+	log "github.com/sirupsen/logrus"
+	"github.com/mitchellh/cli"
+	"strings"
+)
+
+type IncidentPrioritySet struct {
+	Meta
+}
+
+func IncidentPrioritySetCommand() (cli.Command, error) {
+	return &IncidentPrioritySet{}, nil
+}
+
+func (c *IncidentPrioritySet) Help() string {
+	helpText := `
+	pd incident priority set Set the priority of an incident
+
+	Options:
+
+		 -id        Incident ID
+		 -from      Email address of the user making the change
+		 -priority  Priority ID or name, such as P1
+
+	` + c.Meta.Help()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *IncidentPrioritySet) Synopsis() string {
+	return "Set the priority of an incident"
+}
+
+func (c *IncidentPrioritySet) Run(args []string) int {
+	flags := c.Meta.FlagSet("incident priority set")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	incidentID := flags.String("id", "", "Incident ID")
+	from := flags.String("from", "", "Email address of the user making the change")
+	priority := flags.String("priority", "", "Priority ID or name, such as P1")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *incidentID == "" || *from == "" || *priority == "" {
+		log.Error("You must provide an incident id, a from address and a priority")
+		return -1
+	}
+	client := c.Meta.Client()
+	var priorityID string
+	it := client.Iterate(pagerduty.PriorityResourceType)
+	for it.Next() {
+		if p := it.Resource().(pagerduty.Priority); p.Matches(*priority) {
+			priorityID = p.ID
+			it.Stop()
+		}
+	}
+	if err := it.Err(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if priorityID == "" {
+		log.Error("Unknown priority: ", *priority)
+		return -1
+	}
+	o := pagerduty.UpdateIncidentOptions{
+		Priority: &pagerduty.APIReference{ID: priorityID, Type: "priority_reference"},
+	}
+	incident, err := client.UpdateIncident(*from, *incidentID, o)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("Incident", incident.ID, "priority set to", *priority)
+	return 0
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	"github.com/kylie-a/go-pagerduty"
+	log "github.com/sirupsen/logrus"
+	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v2"
+	"strings"
+)
+
+type IncidentResponderAdd struct {
+	Meta
+}
+
+func IncidentResponderAddCommand() (cli.Command, error) {
+	return &IncidentResponderAdd{}, nil
+}
+
+func (c *IncidentResponderAdd) Help() string {
+	helpText := `
+	pd incident responder add Request additional responders to an incident
+
+	Options:
+
+		 -id                 Incident ID
+		 -from               Email address of the requesting user
+		 -requester          ID of the requesting user
+		 -message            Message sent to the responders
+		 -user               User ID (can be specified multiple times)
+		 -escalation-policy  Escalation policy ID (can be specified multiple times)
+
+	` + c.Meta.Help()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *IncidentResponderAdd) Synopsis() string {
+	return "Request users or escalation policies to respond to an incident"
+}
+
+func (c *IncidentResponderAdd) Run(args []string) int {
+	var users, policies []string
+	flags := c.Meta.FlagSet("incident responder add")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	incidentID := flags.String("id", "", "Incident ID")
+	from := flags.String("from", "", "Email address of the requesting user")
+	requester := flags.String("requester", "", "ID of the requesting user")
+	message := flags.String("message", "", "Message sent to the responders")
+	flags.Var((*ArrayFlags)(&users), "user", "User ID (can be specified multiple times)")
+	flags.Var((*ArrayFlags)(&policies), "escalation-policy", "Escalation policy ID (can be specified multiple times)")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *incidentID == "" || *from == "" || *requester == "" {
+		log.Error("You must provide an incident id, a from address and a requester id")
+		return -1
+	}
+	o := pagerduty.ResponderRequestOptions{RequesterID: *requester, Message: *message}
+	for _, id := range users {
+		o.Targets = append(o.Targets, pagerduty.APIReference{ID: id, Type: pagerduty.ResponderTargetUser})
+	}
+	for _, id := range policies {
+		o.Targets = append(o.Targets, pagerduty.APIReference{ID: id, Type: pagerduty.ResponderTargetEscalationPolicy})
+	}
+	if len(o.Targets) == 0 {
+		log.Error("You must provide at least one user or escalation policy")
+		return -1
+	}
+	client := c.Meta.Client()
+	rr, err := client.CreateResponderRequest(*from, *incidentID, o)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	data, err := yaml.Marshal(rr)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	fmt.Println(string(data))
+	return 0
+}
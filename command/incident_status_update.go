@@ -0,0 +1,82 @@
+package main
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v2"
+	"strings"
+)
+
+type IncidentStatusUpdate struct {
+	Meta
+}
+
+func IncidentStatusUpdateCommand() (cli.Command, error) {
+	return &IncidentStatusUpdate{}, nil
+}
+
+func (c *IncidentStatusUpdate) Help() string {
+	helpText := `
+	pd incident status-update Post a status update to the stakeholders of an incident,
+	or list the status updates posted when no message is given
+
+	Options:
+
+		 -id       Incident ID
+		 -from     Email address of the sending user
+		 -message  Status update message
+
+	` + c.Meta.Help()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *IncidentStatusUpdate) Synopsis() string {
+	return "Post or list the status updates of an incident"
+}
+
+func (c *IncidentStatusUpdate) Run(args []string) int {
+	flags := c.Meta.FlagSet("incident status-update")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	incidentID := flags.String("id", "", "Incident ID")
+	from := flags.String("from", "", "Email address of the sending user")
+	message := flags.String("message", "", "Status update message")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *incidentID == "" {
+		log.Error("You must provide an incident id")
+		return -1
+	}
+	client := c.Meta.Client()
+	if *message == "" {
+		updates, err := client.ListIncidentStatusUpdates(*incidentID)
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		data, err := yaml.Marshal(updates)
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		fmt.Println(string(data))
+		return 0
+	}
+	if *from == "" {
+		log.Error("You must provide a from address to post a status update")
+		return -1
+	}
+	update, err := client.CreateIncidentStatusUpdate(*from, *incidentID, *message)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("New status update id:", update.ID)
+	return 0
+}
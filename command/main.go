@@ -26,12 +26,15 @@ func loadCommands() map[string]cli.CommandFactory {
 		"escalation-policy show":   EscalationPolicyShowCommand,
 		"escalation-policy update": EscalationPolicyUpdateCommand,
 
-		"incident list":        IncidentListCommand,
-		"incident manage":      IncidentManageCommand,
-		"incident show":        IncidentShowCommand,
-		"incident note list":   IncidentNoteListCommand,
-		"incident note create": IncidentNoteCreateCommand,
-		"incident snooze":      IncidentSnoozeCommand,
+		"incident list":          IncidentListCommand,
+		"incident manage":        IncidentManageCommand,
+		"incident show":          IncidentShowCommand,
+		"incident note list":     IncidentNoteListCommand,
+		"incident note create":   IncidentNoteCreateCommand,
+		"incident snooze":        IncidentSnoozeCommand,
+		"incident priority set":  IncidentPrioritySetCommand,
+		"incident responder add": IncidentResponderAddCommand,
+		"incident status-update": IncidentStatusUpdateCommand,
 
 		"log-entry list": LogEntryListCommand,
 		"log-entry show": LogEntryShowCommand,
@@ -69,7 +72,7 @@ func loadCommands() map[string]cli.CommandFactory {
 		"service integration update": ServiceIntegrationUpdateCommand,
 
 		"team list":                     TeamListCommand,
-		"team create":                   TeamShowCommand,
+		"team create":                   TeamCreateCommand,
 		"team delete":                   TeamDeleteCommand,
 		"team show":                     TeamShowCommand,
 		"team update":                   TeamUpdateCommand,
@@ -82,6 +85,8 @@ func loadCommands() map[string]cli.CommandFactory {
 		"user delete":                   UserDeleteCommand,
 		"user show":                     UserShowCommand,
 		"user update":                   UserUpdateCommand,
+		"user whoami":                   UserWhoamiCommand,
+		"user oncall":                   UserOncallCommand,
 		"user contact-method list":      UserContactMethodListCommand,
 		"user contact-method create":    UserContactMethodCreateCommand,
 		"user contact-method delete":    UserContactMethodDeleteCommand,
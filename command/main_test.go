@@ -1,6 +1,16 @@
 package main
 
-import "testing"
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/mitchellh/go-homedir"
+	log "github.com/sirupsen/logrus"
+)
 
 func TestInvokeCLIVersion(t *testing.T) {
 	args := []string{"-v"}
@@ -8,3 +18,55 @@ func TestInvokeCLIVersion(t *testing.T) {
 		t.Errorf("`pd -v` return code is non zero")
 	}
 }
+
+func TestTeamCreateCommand(t *testing.T) {
+	cmd, err := loadCommands()["team create"]()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := cmd.(*TeamCreate); !ok {
+		t.Errorf("`pd team create` runs %T", cmd)
+	}
+}
+
+func TestMetaSetupLoglevelFromConfig(t *testing.T) {
+	home, err := ioutil.TempDir("", "pd")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(home)
+	config := []byte("authtoken: token\nloglevel: debug\n")
+	if err := ioutil.WriteFile(filepath.Join(home, ".pd.yml"), config, 0600); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Setenv("HOME", os.Getenv("HOME"))
+	os.Setenv("HOME", home)
+	homedir.DisableCache = true
+	defer func() { homedir.DisableCache = false }()
+	defer log.SetLevel(log.GetLevel())
+
+	var m Meta
+	if err := m.Setup(); err != nil {
+		t.Fatal(err)
+	}
+	if log.GetLevel() != log.DebugLevel {
+		t.Errorf("expected the configured debug level, got %s", log.GetLevel())
+	}
+	if len(m.clientOptions) != 1 {
+		t.Errorf("expected the debug logger to be installed, got %d client options", len(m.clientOptions))
+	}
+}
+
+func TestTeamAddUserRejectsUnknownRole(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	cmd := &TeamAddUser{}
+	if code := cmd.Run([]string{"-id", "PT1", "-user-id", "PU1", "-role", "admin"}); code == 0 {
+		t.Error("expected an unknown role to be rejected")
+	}
+	if out := buf.String(); !strings.Contains(out, "observer, responder or manager") {
+		t.Errorf("expected the allowed roles to be printed, got %q", out)
+	}
+}
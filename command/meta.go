@@ -3,7 +3,7 @@ package main
 import (
 	"flag"
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/go-homedir"
 	"gopkg.in/yaml.v2"
@@ -30,6 +30,8 @@ func (a *ArrayFlags) Set(v string) error {
 type Meta struct {
 	Authtoken string
 	Loglevel  string
+
+	clientOptions []pagerduty.NewClientOptionFunc
 }
 
 type FlagSetFlags uint
@@ -42,7 +44,7 @@ func (m *Meta) FlagSet(n string) *flag.FlagSet {
 }
 
 func (m *Meta) Client() *pagerduty.Client {
-	return pagerduty.NewClient(m.Authtoken)
+	return pagerduty.NewClient(m.Authtoken, m.clientOptions...)
 }
 
 func (m *Meta) Help() string {
@@ -63,8 +65,9 @@ func (m *Meta) validate() error {
 }
 
 func (m *Meta) Setup() error {
+	err := m.loadConfig()
 	m.setupLogging()
-	if err := m.loadConfig(); err != nil {
+	if err != nil {
 		log.Warn(err)
 	}
 	return m.validate()
@@ -79,11 +82,34 @@ func (m *Meta) setupLogging() {
 		log.SetLevel(log.WarnLevel)
 	case "debug":
 		log.SetLevel(log.DebugLevel)
+		m.clientOptions = append(m.clientOptions, pagerduty.WithDebugLogger(pagerduty.LoggerFunc(logAPIRequest)))
 	default:
 		log.Fatal("Unknown log level", m.Loglevel)
 	}
 }
 
+func logAPIRequest(l pagerduty.RequestLog) {
+	entry := log.WithFields(log.Fields{
+		"method":     l.Method,
+		"path":       l.Path,
+		"query":      l.Query,
+		"status":     l.StatusCode,
+		"latency":    l.Latency,
+		"request_id": l.RequestID,
+		"attempts":   l.Attempts,
+	})
+	if l.Err != nil {
+		entry = entry.WithError(l.Err)
+	}
+	entry.Debug("API request")
+	if l.RequestBody != "" {
+		entry.Debugf("Request body: %s", l.RequestBody)
+	}
+	if l.ResponseBody != "" {
+		entry.Debugf("Response body: %s", l.ResponseBody)
+	}
+}
+
 func (m *Meta) loadConfig() error {
 	path, err := homedir.Dir()
 	if err != nil {
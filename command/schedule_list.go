@@ -6,7 +6,7 @@ import (
 
 	yaml "gopkg.in/yaml.v2"
 
-	pagerduty "github.com/PagerDuty/go-pagerduty"
+	pagerduty "github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 )
@@ -50,7 +50,7 @@ func (c *ScheduleList) Run(args []string) int {
 	opts := pagerduty.ListSchedulesOptions{
 		Query: query,
 	}
-	if scheduleList, err := client.ListSchedules(opts); err != nil {
+	if scheduleList, err := client.ListSchedules(pagerduty.WithOptions(opts)); err != nil {
 		log.Error(err)
 		return -1
 	} else {
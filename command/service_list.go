@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"gopkg.in/yaml.v2"
@@ -64,7 +64,7 @@ func (c *ServiceList) Run(args []string) int {
 		Query:    query,
 		Includes: includes,
 	}
-	if serviceList, err := client.ListServices(opts); err != nil {
+	if serviceList, err := client.ListServices(pagerduty.WithOptions(opts)); err != nil {
 		log.Error(err)
 		return -1
 	} else {
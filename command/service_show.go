@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"gopkg.in/yaml.v2"
@@ -56,7 +56,7 @@ func (c *ServiceShow) Run(args []string) int {
 	o := &pagerduty.GetServiceOptions{
 		Includes: includes,
 	}
-	servicerecord, err := client.GetService(*servID, o)
+	servicerecord, err := client.GetService(*servID, pagerduty.WithOptions(o))
 	if err != nil {
 		log.Error(err)
 		return -1
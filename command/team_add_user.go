@@ -1,11 +1,15 @@
 package main
 
 import (
+	"fmt"
+	"github.com/kylie-a/go-pagerduty"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type TeamAddUser struct {
+	Meta
 }
 
 func TeamAddUserCommand() (cli.Command, error) {
@@ -14,7 +18,15 @@ func TeamAddUserCommand() (cli.Command, error) {
 
 func (c *TeamAddUser) Help() string {
 	helpText := `
-	`
+	team add user Add a user to a team, or change their role in the team
+
+	Options:
+		 -id       Team ID
+		 -user-id  User ID
+		 -role     Role of the user in the team: observer, responder or manager.
+		           The default role of the account is used when omitted.
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +35,41 @@ func (c *TeamAddUser) Synopsis() string {
 }
 
 func (c *TeamAddUser) Run(args []string) int {
+	var id, userID, role string
+	flags := c.Meta.FlagSet("team add user")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	flags.StringVar(&id, "id", "", "Team ID")
+	flags.StringVar(&userID, "user-id", "", "User ID")
+	flags.StringVar(&role, "role", "", "Role of the user in the team: observer, responder or manager")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	switch pagerduty.TeamRole(role) {
+	case "", pagerduty.TeamRoleObserver, pagerduty.TeamRoleResponder, pagerduty.TeamRoleManager:
+	default:
+		log.Errorf("Invalid role %q, it must be one of observer, responder or manager", role)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if id == "" || userID == "" {
+		log.Error("You must specify the team id and the user id using -id and -user-id flags")
+		return -1
+	}
+	client := c.Meta.Client()
+	var err error
+	if role == "" {
+		err = client.AddUserToTeam(id, userID)
+	} else {
+		err = client.AddUserToTeamWithRole(id, userID, pagerduty.TeamRole(role))
+	}
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("Added user", userID, "to team", id)
 	return 0
 }
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"fmt"
+	"github.com/kylie-a/go-pagerduty"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type TeamCreate struct {
+	Meta
 }
 
 func TeamCreateCommand() (cli.Command, error) {
@@ -14,7 +18,14 @@ func TeamCreateCommand() (cli.Command, error) {
 
 func (c *TeamCreate) Help() string {
 	helpText := `
-	`
+	team create Create a new team
+
+	Options:
+		 -name         Team name
+		 -description  Team description
+		 -parent       ID of the parent team, to create a sub-team
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +34,34 @@ func (c *TeamCreate) Synopsis() string {
 }
 
 func (c *TeamCreate) Run(args []string) int {
+	var t pagerduty.Team
+	var parent string
+	flags := c.Meta.FlagSet("team create")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	flags.StringVar(&t.Name, "name", "", "Team name")
+	flags.StringVar(&t.Description, "description", "", "Team description")
+	flags.StringVar(&parent, "parent", "", "ID of the parent team")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if t.Name == "" {
+		log.Error("You must specify the team name using -name flag")
+		return -1
+	}
+	if parent != "" {
+		t.Parent = &pagerduty.APIObject{ID: parent, Type: "team_reference"}
+	}
+	client := c.Meta.Client()
+	team, err := client.CreateTeam(&t)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("New team id:", team.ID)
 	return 0
 }
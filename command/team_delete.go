@@ -1,11 +1,14 @@
 package main
 
 import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type TeamDelete struct {
+	Meta
 }
 
 func TeamDeleteCommand() (cli.Command, error) {
@@ -14,7 +17,12 @@ func TeamDeleteCommand() (cli.Command, error) {
 
 func (c *TeamDelete) Help() string {
 	helpText := `
-	`
+	team delete Remove an existing team
+
+	Options:
+		 -id     Team ID
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +31,27 @@ func (c *TeamDelete) Synopsis() string {
 }
 
 func (c *TeamDelete) Run(args []string) int {
+	var id string
+	flags := c.Meta.FlagSet("team delete")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	flags.StringVar(&id, "id", "", "Team ID")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if id == "" {
+		log.Error("You must specify the team id using -id flag")
+		return -1
+	}
+	client := c.Meta.Client()
+	if err := client.DeleteTeam(id); err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("Deleted team", id)
 	return 0
 }
@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"gopkg.in/yaml.v2"
@@ -48,7 +48,7 @@ func (c *TeamList) Run(args []string) int {
 	o := pagerduty.ListTeamOptions{
 		Query: query,
 	}
-	result, err := client.ListTeams(o)
+	result, err := client.ListTeams(pagerduty.WithOptions(o))
 	if err != nil {
 		log.Error(err)
 		return -1
@@ -1,11 +1,16 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	"github.com/kylie-a/go-pagerduty"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type TeamUpdate struct {
+	Meta
 }
 
 func TeamUpdateCommand() (cli.Command, error) {
@@ -14,7 +19,16 @@ func TeamUpdateCommand() (cli.Command, error) {
 
 func (c *TeamUpdate) Help() string {
 	helpText := `
-	`
+	team update Update an existing team. Only the options given are changed.
+
+	Options:
+		 -id           Team ID
+		 -name         Team name
+		 -description  Team description
+		 -parent       ID of the parent team. The parent of a team cannot be
+		               removed.
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +37,53 @@ func (c *TeamUpdate) Synopsis() string {
 }
 
 func (c *TeamUpdate) Run(args []string) int {
+	var id, name, description, parent string
+	flags := c.Meta.FlagSet("team update")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	flags.StringVar(&id, "id", "", "Team ID")
+	flags.StringVar(&name, "name", "", "Team name")
+	flags.StringVar(&description, "description", "", "Team description")
+	flags.StringVar(&parent, "parent", "", "ID of the parent team")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if id == "" {
+		log.Error("You must specify the team id using -id flag")
+		return -1
+	}
+	parentSet := false
+	flags.Visit(func(f *flag.Flag) {
+		parentSet = parentSet || f.Name == "parent"
+	})
+	if parentSet && parent == "" {
+		log.Error("The parent of a team cannot be removed, -parent must be a team id")
+		return -1
+	}
+	client := c.Meta.Client()
+	t, err := client.GetTeam(id)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "name":
+			t.Name = name
+		case "description":
+			t.Description = description
+		case "parent":
+			t.Parent = &pagerduty.APIObject{ID: parent, Type: "team_reference"}
+		}
+	})
+	if _, err := client.UpdateTeam(id, t); err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("Updated team", id)
 	return 0
 }
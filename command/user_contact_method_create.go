@@ -1,11 +1,24 @@
 package main
 
 import (
+	"fmt"
+	"github.com/kylie-a/go-pagerduty"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
+// contactMethodTypes maps the contact method types accepted on the command
+// line to their API type.
+var contactMethodTypes = map[string]string{
+	"email": pagerduty.EmailContactMethodType,
+	"phone": pagerduty.PhoneContactMethodType,
+	"sms":   pagerduty.SMSContactMethodType,
+	"push":  pagerduty.PushContactMethodType,
+}
+
 type UserContactMethodCreate struct {
+	Meta
 }
 
 func UserContactMethodCreateCommand() (cli.Command, error) {
@@ -14,7 +27,19 @@ func UserContactMethodCreateCommand() (cli.Command, error) {
 
 func (c *UserContactMethodCreate) Help() string {
 	helpText := `
-	`
+	pd user contact-method create Add a contact method to a user
+
+	Options:
+
+		 -user-id           User ID
+		 -type              Contact method type: email, phone, sms or push
+		 -label             Contact method label, such as Work
+		 -address           Email address, phone number or device token
+		 -country-code      Country code of a phone or sms contact method
+		 -device-type       Device type of a push contact method: android or ios
+		 -send-short-email  Send abbreviated emails
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +48,39 @@ func (c *UserContactMethodCreate) Synopsis() string {
 }
 
 func (c *UserContactMethodCreate) Run(args []string) int {
+	var cm pagerduty.ContactMethod
+	flags := c.Meta.FlagSet("user contact-method create")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	typ := flags.String("type", "", "Contact method type: email, phone, sms or push")
+	flags.StringVar(&cm.Label, "label", "", "Contact method label, such as Work")
+	flags.StringVar(&cm.Address, "address", "", "Email address, phone number or device token")
+	flags.IntVar(&cm.CountryCode, "country-code", 0, "Country code of a phone or sms contact method")
+	flags.StringVar(&cm.DeviceType, "device-type", "", "Device type of a push contact method: android or ios")
+	flags.BoolVar(&cm.SendShortEmail, "send-short-email", false, "Send abbreviated emails")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" {
+		log.Error("You must provide a user id")
+		return -1
+	}
+	var ok bool
+	if cm.Type, ok = contactMethodTypes[*typ]; !ok {
+		log.Error("Unknown contact method type: ", *typ)
+		return -1
+	}
+	client := c.Meta.Client()
+	created, err := client.CreateUserContactMethod(*userID, cm)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("New contact method id:", created.ID)
 	return 0
 }
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type UserContactMethodDelete struct {
+	Meta
 }
 
 func UserContactMethodDeleteCommand() (cli.Command, error) {
@@ -14,7 +17,14 @@ func UserContactMethodDeleteCommand() (cli.Command, error) {
 
 func (c *UserContactMethodDelete) Help() string {
 	helpText := `
-	`
+	pd user contact-method delete Remove a contact method from a user
+
+	Options:
+
+		 -user-id  User ID
+		 -id       Contact method ID
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +33,27 @@ func (c *UserContactMethodDelete) Synopsis() string {
 }
 
 func (c *UserContactMethodDelete) Run(args []string) int {
+	flags := c.Meta.FlagSet("user contact-method delete")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	id := flags.String("id", "", "Contact method ID")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" || *id == "" {
+		log.Error("You must provide a user id and a contact method id")
+		return -1
+	}
+	client := c.Meta.Client()
+	if err := client.DeleteUserContactMethod(*userID, *id); err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("Deleted contact method", *id)
 	return 0
 }
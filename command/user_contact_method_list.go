@@ -1,11 +1,15 @@
 package main
 
 import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v2"
 	"strings"
 )
 
 type UserContactMethodList struct {
+	Meta
 }
 
 func UserContactMethodListCommand() (cli.Command, error) {
@@ -14,7 +18,13 @@ func UserContactMethodListCommand() (cli.Command, error) {
 
 func (c *UserContactMethodList) Help() string {
 	helpText := `
-	`
+	pd user contact-method list List the contact methods of a user
+
+	Options:
+
+		 -user-id  User ID
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +33,35 @@ func (c *UserContactMethodList) Synopsis() string {
 }
 
 func (c *UserContactMethodList) Run(args []string) int {
+	flags := c.Meta.FlagSet("user contact-method list")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" {
+		log.Error("You must provide a user id")
+		return -1
+	}
+	client := c.Meta.Client()
+	contactMethods, err := client.ListUserContactMethods(*userID)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	for i, cm := range contactMethods {
+		fmt.Println("Entry: ", i)
+		data, err := yaml.Marshal(cm)
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		fmt.Println(string(data))
+	}
 	return 0
 }
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v2"
 	"strings"
 )
 
 type UserContactMethodShow struct {
+	Meta
 }
 
 func UserContactMethodShowCommand() (cli.Command, error) {
@@ -14,7 +18,14 @@ func UserContactMethodShowCommand() (cli.Command, error) {
 
 func (c *UserContactMethodShow) Help() string {
 	helpText := `
-	`
+	pd user contact-method show Show a contact method of a user
+
+	Options:
+
+		 -user-id  User ID
+		 -id       Contact method ID
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +34,33 @@ func (c *UserContactMethodShow) Synopsis() string {
 }
 
 func (c *UserContactMethodShow) Run(args []string) int {
+	flags := c.Meta.FlagSet("user contact-method show")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	id := flags.String("id", "", "Contact method ID")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" || *id == "" {
+		log.Error("You must provide a user id and a contact method id")
+		return -1
+	}
+	client := c.Meta.Client()
+	cm, err := client.GetUserContactMethod(*userID, *id)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	data, err := yaml.Marshal(cm)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	fmt.Println(string(data))
 	return 0
 }
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type UserContactMethodUpdate struct {
+	Meta
 }
 
 func UserContactMethodUpdateCommand() (cli.Command, error) {
@@ -14,7 +18,19 @@ func UserContactMethodUpdateCommand() (cli.Command, error) {
 
 func (c *UserContactMethodUpdate) Help() string {
 	helpText := `
-	`
+	pd user contact-method update Update a contact method of a user. Only the
+	options given are changed.
+
+	Options:
+
+		 -user-id           User ID
+		 -id                Contact method ID
+		 -label             Contact method label, such as Work
+		 -address           Email address, phone number or device token
+		 -country-code      Country code of a phone or sms contact method
+		 -send-short-email  Send abbreviated emails
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +39,48 @@ func (c *UserContactMethodUpdate) Synopsis() string {
 }
 
 func (c *UserContactMethodUpdate) Run(args []string) int {
+	flags := c.Meta.FlagSet("user contact-method update")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	id := flags.String("id", "", "Contact method ID")
+	label := flags.String("label", "", "Contact method label, such as Work")
+	address := flags.String("address", "", "Email address, phone number or device token")
+	countryCode := flags.Int("country-code", 0, "Country code of a phone or sms contact method")
+	sendShortEmail := flags.Bool("send-short-email", false, "Send abbreviated emails")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" || *id == "" {
+		log.Error("You must provide a user id and a contact method id")
+		return -1
+	}
+	client := c.Meta.Client()
+	cm, err := client.GetUserContactMethod(*userID, *id)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "label":
+			cm.Label = *label
+		case "address":
+			cm.Address = *address
+		case "country-code":
+			cm.CountryCode = *countryCode
+		case "send-short-email":
+			cm.SendShortEmail = *sendShortEmail
+		}
+	})
+	if _, err := client.UpdateUserContactMethod(*userID, *cm); err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("Updated contact method", cm.ID)
 	return 0
 }
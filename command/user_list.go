@@ -2,7 +2,7 @@ package main
 
 import (
 	"fmt"
-	"github.com/PagerDuty/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty"
 	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"gopkg.in/yaml.v2"
@@ -60,7 +60,7 @@ func (c *UserList) Run(args []string) int {
 		TeamIDs:  teamIDs,
 		Includes: includes,
 	}
-	if resp, err := client.ListUsers(opts); err != nil {
+	if resp, err := client.ListUsers(pagerduty.WithOptions(opts)); err != nil {
 		log.Error(err)
 		return -1
 	} else {
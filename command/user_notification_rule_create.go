@@ -1,11 +1,15 @@
 package main
 
 import (
+	"fmt"
+	"github.com/kylie-a/go-pagerduty"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type UserNotificationRuleCreate struct {
+	Meta
 }
 
 func UserNotificationRuleCreateCommand() (cli.Command, error) {
@@ -14,7 +18,16 @@ func UserNotificationRuleCreateCommand() (cli.Command, error) {
 
 func (c *UserNotificationRuleCreate) Help() string {
 	helpText := `
-	`
+	pd user notification-rule create Add a notification rule to a user
+
+	Options:
+
+		 -user-id            User ID
+		 -contact-method-id  ID of the contact method to notify
+		 -start-delay        Minutes to wait before notifying the contact method
+		 -urgency            Urgency of the incidents the rule applies to: high or low
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +36,40 @@ func (c *UserNotificationRuleCreate) Synopsis() string {
 }
 
 func (c *UserNotificationRuleCreate) Run(args []string) int {
+	flags := c.Meta.FlagSet("user notification-rule create")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	contactMethodID := flags.String("contact-method-id", "", "ID of the contact method to notify")
+	startDelay := flags.Uint("start-delay", 0, "Minutes to wait before notifying the contact method")
+	urgency := flags.String("urgency", "high", "Urgency of the incidents the rule applies to: high or low")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" || *contactMethodID == "" {
+		log.Error("You must provide a user id and a contact method id")
+		return -1
+	}
+	client := c.Meta.Client()
+	cm, err := client.GetUserContactMethod(*userID, *contactMethodID)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	rule := pagerduty.NotificationRule{
+		ContactMethod:       *cm,
+		StartDelayInMinutes: *startDelay,
+		Urgency:             *urgency,
+	}
+	created, err := client.CreateUserNotificationRule(*userID, rule)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("New notification rule id:", created.ID)
 	return 0
 }
@@ -1,11 +1,14 @@
 package main
 
 import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type UserNotificationRuleDelete struct {
+	Meta
 }
 
 func UserNotificationRuleDeleteCommand() (cli.Command, error) {
@@ -14,7 +17,14 @@ func UserNotificationRuleDeleteCommand() (cli.Command, error) {
 
 func (c *UserNotificationRuleDelete) Help() string {
 	helpText := `
-	`
+	pd user notification-rule delete Remove a notification rule from a user
+
+	Options:
+
+		 -user-id  User ID
+		 -id       Notification rule ID
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +33,27 @@ func (c *UserNotificationRuleDelete) Synopsis() string {
 }
 
 func (c *UserNotificationRuleDelete) Run(args []string) int {
+	flags := c.Meta.FlagSet("user notification-rule delete")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	id := flags.String("id", "", "Notification rule ID")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" || *id == "" {
+		log.Error("You must provide a user id and a notification rule id")
+		return -1
+	}
+	client := c.Meta.Client()
+	if err := client.DeleteUserNotificationRule(*userID, *id); err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("Deleted notification rule", *id)
 	return 0
 }
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v2"
 	"strings"
 )
 
 type UserNotificationRuleList struct {
+	Meta
 }
 
 func UserNotificationRuleListCommand() (cli.Command, error) {
@@ -14,7 +18,13 @@ func UserNotificationRuleListCommand() (cli.Command, error) {
 
 func (c *UserNotificationRuleList) Help() string {
 	helpText := `
-	`
+	pd user notification-rule list List the notification rules of a user
+
+	Options:
+
+		 -user-id  User ID
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +33,35 @@ func (c *UserNotificationRuleList) Synopsis() string {
 }
 
 func (c *UserNotificationRuleList) Run(args []string) int {
+	flags := c.Meta.FlagSet("user notification-rule list")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" {
+		log.Error("You must provide a user id")
+		return -1
+	}
+	client := c.Meta.Client()
+	rules, err := client.ListUserNotificationRules(*userID)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	for i, rule := range rules {
+		fmt.Println("Entry: ", i)
+		data, err := yaml.Marshal(rule)
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		fmt.Println(string(data))
+	}
 	return 0
 }
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v2"
 	"strings"
 )
 
 type UserNotificationRuleShow struct {
+	Meta
 }
 
 func UserNotificationRuleShowCommand() (cli.Command, error) {
@@ -14,7 +18,14 @@ func UserNotificationRuleShowCommand() (cli.Command, error) {
 
 func (c *UserNotificationRuleShow) Help() string {
 	helpText := `
-	`
+	pd user notification-rule show Show a notification rule of a user
+
+	Options:
+
+		 -user-id  User ID
+		 -id       Notification rule ID
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +34,33 @@ func (c *UserNotificationRuleShow) Synopsis() string {
 }
 
 func (c *UserNotificationRuleShow) Run(args []string) int {
+	flags := c.Meta.FlagSet("user notification-rule show")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	id := flags.String("id", "", "Notification rule ID")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" || *id == "" {
+		log.Error("You must provide a user id and a notification rule id")
+		return -1
+	}
+	client := c.Meta.Client()
+	rule, err := client.GetUserNotificationRule(*userID, *id)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	data, err := yaml.Marshal(rule)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	fmt.Println(string(data))
 	return 0
 }
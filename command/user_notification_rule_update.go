@@ -1,11 +1,15 @@
 package main
 
 import (
+	"flag"
+	"fmt"
+	log "github.com/sirupsen/logrus"
 	"github.com/mitchellh/cli"
 	"strings"
 )
 
 type UserNotificationRuleUpdate struct {
+	Meta
 }
 
 func UserNotificationRuleUpdateCommand() (cli.Command, error) {
@@ -14,7 +18,18 @@ func UserNotificationRuleUpdateCommand() (cli.Command, error) {
 
 func (c *UserNotificationRuleUpdate) Help() string {
 	helpText := `
-	`
+	pd user notification-rule update Update a notification rule of a user. Only
+	the options given are changed.
+
+	Options:
+
+		 -user-id            User ID
+		 -id                 Notification rule ID
+		 -contact-method-id  ID of the contact method to notify
+		 -start-delay        Minutes to wait before notifying the contact method
+		 -urgency            Urgency of the incidents the rule applies to: high or low
+
+	` + c.Meta.Help()
 	return strings.TrimSpace(helpText)
 }
 
@@ -23,5 +38,51 @@ func (c *UserNotificationRuleUpdate) Synopsis() string {
 }
 
 func (c *UserNotificationRuleUpdate) Run(args []string) int {
+	flags := c.Meta.FlagSet("user notification-rule update")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("user-id", "", "User ID")
+	id := flags.String("id", "", "Notification rule ID")
+	contactMethodID := flags.String("contact-method-id", "", "ID of the contact method to notify")
+	startDelay := flags.Uint("start-delay", 0, "Minutes to wait before notifying the contact method")
+	urgency := flags.String("urgency", "", "Urgency of the incidents the rule applies to: high or low")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *userID == "" || *id == "" {
+		log.Error("You must provide a user id and a notification rule id")
+		return -1
+	}
+	client := c.Meta.Client()
+	rule, err := client.GetUserNotificationRule(*userID, *id)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	if *contactMethodID != "" {
+		cm, err := client.GetUserContactMethod(*userID, *contactMethodID)
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		rule.ContactMethod = *cm
+	}
+	flags.Visit(func(f *flag.Flag) {
+		switch f.Name {
+		case "start-delay":
+			rule.StartDelayInMinutes = *startDelay
+		case "urgency":
+			rule.Urgency = *urgency
+		}
+	})
+	if _, err := client.UpdateUserNotificationRule(*userID, *rule); err != nil {
+		log.Error(err)
+		return -1
+	}
+	log.Println("Updated notification rule", rule.ID)
 	return 0
 }
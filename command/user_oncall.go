@@ -0,0 +1,87 @@
+package main
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v2"
+	"strings"
+	"time"
+)
+
+type UserOncall struct {
+	Meta
+}
+
+func UserOncallCommand() (cli.Command, error) {
+	return &UserOncall{}, nil
+}
+
+func (c *UserOncall) Help() string {
+	helpText := `
+	pd user oncall Show the current and next on-call shifts of a user
+
+	Options:
+
+		 -id    User ID, defaults to the user of the authentication token
+		 -days  Number of days to look ahead for the next shifts (default 30)
+
+	` + c.Meta.Help()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *UserOncall) Synopsis() string {
+	return "Show when a user is on call"
+}
+
+func (c *UserOncall) Run(args []string) int {
+	flags := c.Meta.FlagSet("user oncall")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	userID := flags.String("id", "", "User ID")
+	days := flags.Int("days", 30, "Number of days to look ahead for the next shifts")
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	client := c.Meta.Client()
+	if *userID == "" {
+		user, err := client.GetCurrentUser()
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		*userID = user.ID
+	}
+	shifts, err := client.GetUserOnCallShifts(*userID, time.Duration(*days)*24*time.Hour)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	if len(shifts.Current) == 0 {
+		fmt.Println("Not on call")
+	}
+	for _, oc := range shifts.Current {
+		fmt.Printf("On call for %s (level %d) until %s\n", oc.EscalationPolicy.Summary, oc.EscalationLevel, untilText(oc.End))
+	}
+	if len(shifts.Next) > 0 {
+		fmt.Println("Next shifts:")
+		data, err := yaml.Marshal(shifts.Next)
+		if err != nil {
+			log.Error(err)
+			return -1
+		}
+		fmt.Println(string(data))
+	}
+	return 0
+}
+
+func untilText(end string) string {
+	if end == "" {
+		return "further notice"
+	}
+	return end
+}
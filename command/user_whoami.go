@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+	log "github.com/sirupsen/logrus"
+	"github.com/mitchellh/cli"
+	"gopkg.in/yaml.v2"
+	"strings"
+)
+
+type UserWhoami struct {
+	Meta
+}
+
+func UserWhoamiCommand() (cli.Command, error) {
+	return &UserWhoami{}, nil
+}
+
+func (c *UserWhoami) Help() string {
+	helpText := `
+	pd user whoami Show the user the authentication token belongs to
+
+	` + c.Meta.Help()
+	return strings.TrimSpace(helpText)
+}
+
+func (c *UserWhoami) Synopsis() string {
+	return "Get details about the user of the authentication token"
+}
+
+func (c *UserWhoami) Run(args []string) int {
+	flags := c.Meta.FlagSet("user whoami")
+	flags.Usage = func() { fmt.Println(c.Help()) }
+	if err := flags.Parse(args); err != nil {
+		log.Error(err)
+		return -1
+	}
+	if err := c.Meta.Setup(); err != nil {
+		log.Error(err)
+		return -1
+	}
+	client := c.Meta.Client()
+	user, err := client.GetCurrentUser()
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	data, err := yaml.Marshal(user)
+	if err != nil {
+		log.Error(err)
+		return -1
+	}
+	fmt.Println(string(data))
+	return 0
+}
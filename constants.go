@@ -2,26 +2,30 @@ package pagerduty
 
 const (
 	apiEndpoint = "https://api.pagerduty.com"
+	eventsEndpoint = "https://events.pagerduty.com"
 	pagerdutyAcceptHeader = "application/vnd.pagerduty+json;version=2"
 
 	// Resource Types
-	AbilityResourceType           APIResourceType = "ability"
-	AddonResourceType             APIResourceType = "addon"
-	EscalationPolicyResourceType  APIResourceType = "escalation_policy"
-	EventResourceType             APIResourceType = "event"
-	ExtensionResourceType         APIResourceType = "extension"
-	IncidentResourceType          APIResourceType = "incident"
-	LogEntryResourceType          APIResourceType = "log_entry"
-	MaintenanceWindowResourceType APIResourceType = "maintenance_window"
-	NotificationResourceType      APIResourceType = "notification"
-	OnCallResourceType            APIResourceType = "on_call"
-	ResponsePlayResourceType      APIResourceType = "response_play"
-	ScheduleResourceType          APIResourceType = "schedule"
-	ServiceResourceType           APIResourceType = "service"
-	TeamResourceType              APIResourceType = "team"
-	UserResourceType              APIResourceType = "user"
-	VendorResourceType            APIResourceType = "vendor"
-	WebhookResourceType           APIResourceType = "webhook"
+	AbilityResourceType             APIResourceType = "ability"
+	AddonResourceType               APIResourceType = "addon"
+	AlertResourceType               APIResourceType = "alert"
+	EscalationPolicyResourceType    APIResourceType = "escalation_policy"
+	EventResourceType               APIResourceType = "event"
+	ExtensionResourceType           APIResourceType = "extension"
+	IncidentResourceType            APIResourceType = "incident"
+	LogEntryResourceType            APIResourceType = "log_entry"
+	MaintenanceWindowResourceType   APIResourceType = "maintenance_window"
+	NotificationResourceType        APIResourceType = "notification"
+	OnCallResourceType              APIResourceType = "on_call"
+	PriorityResourceType            APIResourceType = "priority"
+	ResponsePlayResourceType        APIResourceType = "response_play"
+	ScheduleResourceType            APIResourceType = "schedule"
+	ServiceResourceType             APIResourceType = "service"
+	TeamResourceType                APIResourceType = "team"
+	UserResourceType                APIResourceType = "user"
+	VendorResourceType              APIResourceType = "vendor"
+	WebhookResourceType             APIResourceType = "webhook"
+	WebhookSubscriptionResourceType APIResourceType = "webhook_subscription"
 
 	// Abilities
 	AbilitySSO                                Ability = "sso"
@@ -1,6 +1,11 @@
 package pagerduty
 
-import "fmt"
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
 
 // InvalidResourceTypeError is a custom error type.
 type InvalidResourceTypeError struct {
@@ -78,17 +83,156 @@ var ErrorCode_Message = map[int]string{
 	8008: "user has tried to access an invalid host domain",
 }
 
-// APIError is a custom error type.
+// APIError is returned when PagerDuty answers with a non-success HTTP status.
+// Use errors.As to inspect it, or errors.Is with one of the Err* sentinels.
 type APIError struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+	// Code is the PagerDuty error code, see ErrorCode_Message.
+	Code    int
 	Message string
-	Code int
+	// Errors holds the details given by PagerDuty, usually one per invalid field.
+	Errors []string
+	// RequestID is the X-Request-Id of the response, useful for PagerDuty support.
+	RequestID string
+	Method    string
+	Path      string
 }
 
-func (e APIError) Error() string {
-	return e.Message
+func (e *APIError) Error() string {
+	msg := fmt.Sprintf("non-success HTTP response %d", e.StatusCode)
+	if e.Method != "" {
+		msg += fmt.Sprintf(" (%s %s)", e.Method, e.Path)
+	}
+	if e.Code != 0 {
+		msg += fmt.Sprintf(": code %d", e.Code)
+	}
+	if e.Message != "" {
+		msg += ": " + e.Message
+	}
+	if len(e.Errors) > 0 {
+		msg += " [" + strings.Join(e.Errors, "; ") + "]"
+	}
+	if e.RequestID != "" {
+		msg += " (request ID " + e.RequestID + ")"
+	}
+	return msg
+}
+
+// Is reports whether target is an *APIError with the same HTTP status and, if
+// the target sets one, the same PagerDuty code. It makes the Err* sentinels
+// usable with errors.Is.
+func (e *APIError) Is(target error) bool {
+	t, ok := target.(*APIError)
+	if !ok {
+		return false
+	}
+	if t.StatusCode != 0 && t.StatusCode != e.StatusCode {
+		return false
+	}
+	return t.Code == 0 || t.Code == e.Code
+}
+
+// ValidationError is returned before anything is sent when a request is
+// missing a required field or has an invalid value.
+type ValidationError struct {
+	// Field is the JSON name of the invalid field, such as "payload.severity".
+	Field  string
+	Reason string
+}
+
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("invalid %s: %s", e.Field, e.Reason)
 }
 
 // NewAPIError creates a new `APIError`.
-func NewAPIError(code int) APIError {
-	return APIError{Code: code, Message: ErrorCode_Message[code]}
+func NewAPIError(code int) *APIError {
+	return &APIError{Code: code, Message: ErrorCode_Message[code]}
+}
+
+// Sentinel errors matching API errors through errors.Is.
+var (
+	ErrBadRequest   = &APIError{StatusCode: http.StatusBadRequest}
+	ErrUnauthorized = &APIError{StatusCode: http.StatusUnauthorized}
+	ErrForbidden    = &APIError{StatusCode: http.StatusForbidden}
+	ErrNotFound     = &APIError{StatusCode: http.StatusNotFound}
+	ErrConflict     = &APIError{StatusCode: http.StatusConflict}
+	ErrRateLimited  = &APIError{StatusCode: http.StatusTooManyRequests}
+)
+
+// IsBadRequest reports whether err is an API error caused by an invalid
+// request, such as a malformed event.
+func IsBadRequest(err error) bool {
+	return errors.Is(err, ErrBadRequest)
+}
+
+// IsNotFound reports whether err is an API error for a missing resource.
+func IsNotFound(err error) bool {
+	return errors.Is(err, ErrNotFound)
+}
+
+// IsRateLimited reports whether err is an API error caused by rate limiting.
+func IsRateLimited(err error) bool {
+	return errors.Is(err, ErrRateLimited)
+}
+
+// IsConflict reports whether err is an API error caused by a conflicting
+// change, such as a resource that already exists.
+func IsConflict(err error) bool {
+	return errors.Is(err, ErrConflict)
+}
+
+// IsUnauthorized reports whether err is an API error caused by a missing or
+// invalid auth token.
+func IsUnauthorized(err error) bool {
+	return errors.Is(err, ErrUnauthorized)
+}
+
+// HasErrorCode reports whether err is an API error with the given PagerDuty
+// code, such as 1001 for an incident that is already resolved.
+func HasErrorCode(err error, code int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.Code == code
+}
+
+// newAPIError builds the APIError for a non-success response to req, reading
+// the PagerDuty error object from its body when there is one.
+func newAPIError(req *http.Request, resp *http.Response) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		RequestID:  resp.Header.Get("X-Request-Id"),
+	}
+	if req != nil {
+		e.Method = req.Method
+		e.Path = req.URL.Path
+	}
+	var result ErrorResponse
+	if err := deserialize(resp, &result); err == nil {
+		e.Code = result.Error.Code
+		e.Message = result.Error.Message
+		e.Errors = errorDetails(result.Error.Errors)
+	}
+	if e.Message == "" {
+		e.Message = ErrorCode_Message[e.Code]
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(e.StatusCode)
+	}
+	return e
+}
+
+// errorDetails flattens the errors field of an error object.
+func errorDetails(v interface{}) []string {
+	switch errs := v.(type) {
+	case nil:
+		return nil
+	case []interface{}:
+		details := make([]string, 0, len(errs))
+		for _, e := range errs {
+			details = append(details, fmt.Sprint(e))
+		}
+		return details
+	default:
+		return []string{fmt.Sprint(errs)}
+	}
 }
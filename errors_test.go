@@ -0,0 +1,78 @@
+package pagerduty
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func errorServer(status int, body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-123")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+}
+
+func TestAPIErrorFields(t *testing.T) {
+	server := errorServer(http.StatusBadRequest, `{"error": {"code": 1001, "message": "Incident Already Resolved", "errors": ["Incident PIJ90N7 is resolved"]}}`)
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	err := client.SnoozeIncident("PIJ90N7", 60)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("expected an *APIError, got %T: %v", err, err)
+	}
+	expected := APIError{
+		StatusCode: http.StatusBadRequest,
+		Code:       1001,
+		Message:    "Incident Already Resolved",
+		Errors:     []string{"Incident PIJ90N7 is resolved"},
+		RequestID:  "req-123",
+		Method:     http.MethodPost,
+		Path:       "/incidents/PIJ90N7/snooze",
+	}
+	if apiErr.Error() != expected.Error() {
+		t.Errorf("expected %q, got %q", expected.Error(), apiErr.Error())
+	}
+	if !HasErrorCode(err, 1001) || HasErrorCode(err, 2010) {
+		t.Errorf("HasErrorCode did not match code 1001 only")
+	}
+}
+
+func TestAPIErrorSentinels(t *testing.T) {
+	cases := []struct {
+		status int
+		check  func(error) bool
+	}{
+		{http.StatusNotFound, IsNotFound},
+		{http.StatusTooManyRequests, IsRateLimited},
+		{http.StatusConflict, IsConflict},
+		{http.StatusUnauthorized, IsUnauthorized},
+	}
+	for _, c := range cases {
+		server := errorServer(c.status, `not json`)
+		client := NewClient("123", WithCustomHost(server.URL))
+		_, err := client.GetService("PSVC1")
+		server.Close()
+		if !c.check(err) {
+			t.Errorf("status %d: check did not match %v", c.status, err)
+		}
+		if c.status != http.StatusNotFound && IsNotFound(err) {
+			t.Errorf("status %d: unexpectedly matched IsNotFound", c.status)
+		}
+	}
+}
+
+func TestDeleteResourceError(t *testing.T) {
+	server := errorServer(http.StatusForbidden, `{"error": {"code": 2010, "message": "Access Denied"}}`)
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	err := client.DeleteService("PSVC1")
+	if !errors.Is(err, ErrForbidden) || !errors.Is(err, &APIError{Code: 2010}) {
+		t.Errorf("expected an access denied error, got %v", err)
+	}
+}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -50,6 +51,14 @@ type ListEscalationPoliciesResponse struct {
 	EscalationPolicies []EscalationPolicy `json:"escalation_policies"`
 }
 
+func (list ListEscalationPoliciesResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.EscalationPolicies))
+	for i, r := range list.EscalationPolicies {
+		resources[i] = r
+	}
+	return resources
+}
+
 type ListEscalationRulesResponse struct {
 	APIListObject
 	EscalationRules []EscalationRule `json:"escalation_rules"`
@@ -72,7 +81,12 @@ type GetEscalationRuleOptions struct {
 
 // ListEscalationPolicies lists all of the existing escalation policies.
 func (c *Client) ListEscalationPolicies(opts ...ResourceRequestOptionFunc) (*ListEscalationPoliciesResponse, error) {
-	resp, err := c.ListResources(EscalationPolicyResourceType, opts...)
+	return c.ListEscalationPoliciesWithContext(context.Background(), opts...)
+}
+
+// ListEscalationPoliciesWithContext is like ListEscalationPolicies but takes a context.
+func (c *Client) ListEscalationPoliciesWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListEscalationPoliciesResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, EscalationPolicyResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -82,7 +96,12 @@ func (c *Client) ListEscalationPolicies(opts ...ResourceRequestOptionFunc) (*Lis
 
 // CreateEscalationPolicy creates a new escalation policy.
 func (c *Client) CreateEscalationPolicy(e EscalationPolicy) (*EscalationPolicy, error) {
-	resp, err := c.CreateResource(e)
+	return c.CreateEscalationPolicyWithContext(context.Background(), e)
+}
+
+// CreateEscalationPolicyWithContext is like CreateEscalationPolicy but takes a context.
+func (c *Client) CreateEscalationPolicyWithContext(ctx context.Context, e EscalationPolicy) (*EscalationPolicy, error) {
+	resp, err := c.CreateResourceWithContext(ctx, e)
 	if err != nil {
 		return nil, err
 	}
@@ -92,8 +111,12 @@ func (c *Client) CreateEscalationPolicy(e EscalationPolicy) (*EscalationPolicy,
 
 // DeleteEscalationPolicy deletes an existing escalation policy and rules.
 func (c *Client) DeleteEscalationPolicy(id string) error {
-	err := c.DeleteResource(EscalationPolicyResourceType, id)
-	return err
+	return c.DeleteEscalationPolicyWithContext(context.Background(), id)
+}
+
+// DeleteEscalationPolicyWithContext is like DeleteEscalationPolicy but takes a context.
+func (c *Client) DeleteEscalationPolicyWithContext(ctx context.Context, id string) error {
+	return c.DeleteResourceWithContext(ctx, EscalationPolicyResourceType, id)
 }
 
 // GetEscalationPolicyOptions is the data structure used when calling the GetEscalationPolicy API endpoint.
@@ -103,7 +126,12 @@ type GetEscalationPolicyOptions struct {
 
 // GetEscalationPolicy gets information about an existing escalation policy and its rules.
 func (c *Client) GetEscalationPolicy(id string, opts ...ResourceRequestOptionFunc) (*EscalationPolicy, error) {
-	res, err := c.GetResource(EscalationPolicyResourceType, id, opts...)
+	return c.GetEscalationPolicyWithContext(context.Background(), id, opts...)
+}
+
+// GetEscalationPolicyWithContext is like GetEscalationPolicy but takes a context.
+func (c *Client) GetEscalationPolicyWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*EscalationPolicy, error) {
+	res, err := c.GetResourceWithContext(ctx, EscalationPolicyResourceType, id, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -113,48 +141,78 @@ func (c *Client) GetEscalationPolicy(id string, opts ...ResourceRequestOptionFun
 
 // UpdateEscalationPolicy updates an existing escalation policy and its rules.
 func (c *Client) UpdateEscalationPolicy(id string, e *EscalationPolicy) (*EscalationPolicy, error) {
+	return c.UpdateEscalationPolicyWithContext(context.Background(), id, e)
+}
+
+// UpdateEscalationPolicyWithContext is like UpdateEscalationPolicy but takes a context.
+func (c *Client) UpdateEscalationPolicyWithContext(ctx context.Context, id string, e *EscalationPolicy) (*EscalationPolicy, error) {
 	data := make(map[string]EscalationPolicy)
 	data["escalation_policy"] = *e
-	resp, err := c.put(escPath+"/"+id, data, nil)
+	resp, err := c.put(ctx, escPath+"/"+id, data)
 	return getEscalationPolicyFromResponse(c, resp, err)
 }
 
 // CreateEscalationRule creates a new escalation rule for an escalation policy
 // and appends it to the end of the existing escalation rules.
 func (c *Client) CreateEscalationRule(escID string, e EscalationRule) (*EscalationRule, error) {
+	return c.CreateEscalationRuleWithContext(context.Background(), escID, e)
+}
+
+// CreateEscalationRuleWithContext is like CreateEscalationRule but takes a context.
+func (c *Client) CreateEscalationRuleWithContext(ctx context.Context, escID string, e EscalationRule) (*EscalationRule, error) {
 	data := make(map[string]EscalationRule)
 	data["escalation_rule"] = e
-	resp, err := c.post(escPath+"/"+escID+"/escalation_rules", data)
+	resp, err := c.post(ctx, escPath+"/"+escID+"/escalation_rules", data)
 	return getEscalationRuleFromResponse(c, resp, err)
 }
 
 // GetEscalationRule gets information about an existing escalation rule.
 func (c *Client) GetEscalationRule(escID string, id string, o *GetEscalationRuleOptions) (*EscalationRule, error) {
+	return c.GetEscalationRuleWithContext(context.Background(), escID, id, o)
+}
+
+// GetEscalationRuleWithContext is like GetEscalationRule but takes a context.
+func (c *Client) GetEscalationRuleWithContext(ctx context.Context, escID string, id string, o *GetEscalationRuleOptions) (*EscalationRule, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get(escPath + "/" + escID + "/escalation_rules/" + id + "?" + v.Encode())
+	resp, err := c.get(ctx, escPath+"/"+escID+"/escalation_rules/"+id+"?"+v.Encode())
 	return getEscalationRuleFromResponse(c, resp, err)
 }
 
 // DeleteEscalationRule deletes an existing escalation rule.
 func (c *Client) DeleteEscalationRule(escID string, id string) error {
-	_, err := c.delete(escPath + "/" + escID + "/escalation_rules/" + id)
+	return c.DeleteEscalationRuleWithContext(context.Background(), escID, id)
+}
+
+// DeleteEscalationRuleWithContext is like DeleteEscalationRule but takes a context.
+func (c *Client) DeleteEscalationRuleWithContext(ctx context.Context, escID string, id string) error {
+	_, err := c.delete(ctx, escPath+"/"+escID+"/escalation_rules/"+id)
 	return err
 }
 
 // UpdateEscalationRule updates an existing escalation rule.
 func (c *Client) UpdateEscalationRule(escID string, id string, e *EscalationRule) (*EscalationRule, error) {
+	return c.UpdateEscalationRuleWithContext(context.Background(), escID, id, e)
+}
+
+// UpdateEscalationRuleWithContext is like UpdateEscalationRule but takes a context.
+func (c *Client) UpdateEscalationRuleWithContext(ctx context.Context, escID string, id string, e *EscalationRule) (*EscalationRule, error) {
 	data := make(map[string]EscalationRule)
 	data["escalation_rule"] = *e
-	resp, err := c.put(escPath+"/"+escID+"/escalation_rules/"+id, data, nil)
+	resp, err := c.put(ctx, escPath+"/"+escID+"/escalation_rules/"+id, data)
 	return getEscalationRuleFromResponse(c, resp, err)
 }
 
 // ListEscalationRules lists all of the escalation rules for an existing escalation policy.
 func (c *Client) ListEscalationRules(escID string) (*ListEscalationRulesResponse, error) {
-	resp, err := c.get(escPath + "/" + escID + "/escalation_rules")
+	return c.ListEscalationRulesWithContext(context.Background(), escID)
+}
+
+// ListEscalationRulesWithContext is like ListEscalationRules but takes a context.
+func (c *Client) ListEscalationRulesWithContext(ctx context.Context, escID string) (*ListEscalationRulesResponse, error) {
+	resp, err := c.get(ctx, escPath+"/"+escID+"/escalation_rules")
 	if err != nil {
 		return nil, err
 	}
@@ -164,10 +222,10 @@ func (c *Client) ListEscalationRules(escID string) (*ListEscalationRulesResponse
 }
 
 func getEscalationRuleFromResponse(c *Client, resp *http.Response, err error) (*EscalationRule, error) {
-	defer resp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	var target map[string]EscalationRule
 	if dErr := deserialize(resp, &target); dErr != nil {
 		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
@@ -181,10 +239,10 @@ func getEscalationRuleFromResponse(c *Client, resp *http.Response, err error) (*
 }
 
 func getEscalationPolicyFromResponse(c *Client, resp *http.Response, err error) (*EscalationPolicy, error) {
-	defer resp.Body.Close()
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 	var target map[string]EscalationPolicy
 	if dErr := deserialize(resp, &target); dErr != nil {
 		return nil, fmt.Errorf("Could not decode JSON response: %v", dErr)
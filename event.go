@@ -2,12 +2,12 @@ package pagerduty
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
-	"fmt"
 	"net/http"
 )
 
-const eventEndPoint = "https://events.pagerduty.com/generic/2010-04-15/create_event.json"
+const eventPath = "/generic/2010-04-15/create_event.json"
 
 // Event stores data for problem reporting, acknowledgement, and resolution.
 type Event struct {
@@ -32,7 +32,12 @@ type EventResponse struct {
 // problem. If you need to provide a custom HTTP client, please use
 // CreateEventWithHTTPClient.
 func CreateEvent(e Event) (*EventResponse, error) {
-	return CreateEventWithHTTPClient(e, defaultHTTPClient)
+	return CreateEventWithHTTPClientAndContext(context.Background(), e, defaultHTTPClient)
+}
+
+// CreateEventWithContext is like CreateEvent but takes a context.
+func CreateEventWithContext(ctx context.Context, e Event) (*EventResponse, error) {
+	return CreateEventWithHTTPClientAndContext(ctx, e, defaultHTTPClient)
 }
 
 // CreateEventWithHTTPClient sends PagerDuty an event to trigger, acknowledge,
@@ -40,19 +45,30 @@ func CreateEvent(e Event) (*EventResponse, error) {
 // default one used by this package doesn't fit your needs. If you don't need a
 // custom HTTP client, please use CreateEvent instead.
 func CreateEventWithHTTPClient(e Event, client HTTPClient) (*EventResponse, error) {
-	data, err := json.Marshal(e)
-	if err != nil {
-		return nil, err
-	}
-	req, _ := http.NewRequest("POST", eventEndPoint, bytes.NewBuffer(data))
-	req.Header.Set("Content-Type", "application/json")
-	resp, err := client.Do(req)
+	return CreateEventWithHTTPClientAndContext(context.Background(), e, client)
+}
+
+// CreateEventWithHTTPClientAndContext is like CreateEventWithHTTPClient but
+// takes a context.
+func CreateEventWithHTTPClientAndContext(ctx context.Context, e Event, client HTTPClient) (*EventResponse, error) {
+	return NewClient("", WithCustomClient(client)).CreateEventWithContext(ctx, e)
+}
+
+// CreateEvent sends PagerDuty an event through the client's HTTP client,
+// retry policy and events rate limiter.
+func (c *Client) CreateEvent(e Event) (*EventResponse, error) {
+	return c.CreateEventWithContext(context.Background(), e)
+}
+
+// CreateEventWithContext is like CreateEvent but takes a context.
+func (c *Client) CreateEventWithContext(ctx context.Context, e Event) (*EventResponse, error) {
+	resp, err := c.postEvent(ctx, eventPath, e)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("HTTP Status Code: %d", resp.StatusCode)
+		return nil, newEventAPIError(resp)
 	}
 	var eventResponse EventResponse
 	if err := json.NewDecoder(resp.Body).Decode(&eventResponse); err != nil {
@@ -60,3 +76,39 @@ func CreateEventWithHTTPClient(e Event, client HTTPClient) (*EventResponse, erro
 	}
 	return &eventResponse, nil
 }
+
+// postEvent sends payload to the events API endpoint at path.
+func (c *Client) postEvent(ctx context.Context, path string, payload interface{}) (*http.Response, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.eventsEndpoint+path, bytes.NewBuffer(data))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	return c.execute(req, RequestInfo{ResourceType: EventResourceType, Operation: "create"}, c.eventsRateLimiter)
+}
+
+// newEventAPIError builds the APIError for a response of the events API, whose
+// errors are reported as {"status": ..., "message": ..., "errors": [...]}.
+func newEventAPIError(resp *http.Response) *APIError {
+	e := &APIError{StatusCode: resp.StatusCode}
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		e.Path = resp.Request.URL.Path
+	}
+	var result struct {
+		Message string   `json:"message"`
+		Errors  []string `json:"errors"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err == nil {
+		e.Message = result.Message
+		e.Errors = result.Errors
+	}
+	if e.Message == "" {
+		e.Message = http.StatusText(e.StatusCode)
+	}
+	return e
+}
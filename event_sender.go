@@ -0,0 +1,417 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Errors returned by EventSender.Send.
+var (
+	ErrEventQueueFull    = errors.New("pagerduty: event queue is full")
+	ErrEventSenderClosed = errors.New("pagerduty: event sender is closed")
+)
+
+const spoolFileSuffix = ".event.json"
+
+// EventSenderStats are counters describing the activity of an EventSender.
+type EventSenderStats struct {
+	// Queued is the number of events waiting to be sent.
+	Queued int
+	// InFlight is the number of events being sent.
+	InFlight int
+	// Sent is the number of events accepted by PagerDuty.
+	Sent int
+	// Failed is the number of events rejected by PagerDuty, or given up on
+	// after every retry failed.
+	Failed int
+	// Dropped is the number of events refused because the queue was full, or
+	// lost because the sender was closed before sending them.
+	Dropped int
+	// Coalesced is the number of events merged into an event still queued.
+	Coalesced int
+}
+
+// EventSenderOptionFunc configures an EventSender.
+type EventSenderOptionFunc func(*EventSender)
+
+// WithSenderWorkers sets the number of events sent concurrently. It defaults
+// to 2, and must be at least 1.
+func WithSenderWorkers(n int) EventSenderOptionFunc {
+	return func(s *EventSender) {
+		s.workers = n
+	}
+}
+
+// WithSenderQueueSize sets the number of events the sender can hold before
+// Send returns ErrEventQueueFull. It defaults to 1000, and must be at least 1.
+func WithSenderQueueSize(n int) EventSenderOptionFunc {
+	return func(s *EventSender) {
+		s.queueSize = n
+	}
+}
+
+// WithSenderRetryPolicy sets how the sender retries events that failed because
+// of a transport error, rate limiting or a server error. Its MaxAttempts,
+// MinBackoff and MaxBackoff are used, and replace the RetryPolicy of the
+// client for the events sent.
+func WithSenderRetryPolicy(p RetryPolicy) EventSenderOptionFunc {
+	return func(s *EventSender) {
+		s.retryPolicy = p
+	}
+}
+
+// WithSpoolDir makes the sender keep a copy of every pending event in dir, so
+// events not sent when the process stops are sent by the next EventSender
+// using the same directory.
+func WithSpoolDir(dir string) EventSenderOptionFunc {
+	return func(s *EventSender) {
+		s.spoolDir = dir
+	}
+}
+
+// queuedEvent is an event waiting to be sent.
+type queuedEvent struct {
+	event V2Event
+	// file is the path of the spooled copy of the event, if any.
+	file string
+}
+
+// EventSender sends Events API v2 events in the background, so callers are
+// neither blocked nor made to lose alerts by an events API outage. Events are
+// queued in memory, and optionally spooled to disk, then sent by a pool of
+// workers retrying failures with backoff.
+//
+// An event replaces the last event still waiting with the same dedup key when
+// both have the same action, such as two triggers. Otherwise it is queued
+// after it, so a trigger, resolve and trigger for one key are all sent, in
+// that order.
+type EventSender struct {
+	client      *Client
+	workers     int
+	queueSize   int
+	retryPolicy RetryPolicy
+	spoolDir    string
+
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	mu      sync.Mutex
+	cond    *sync.Cond
+	queue   []*queuedEvent
+	sending map[string]bool
+	seq     int64
+	closed  bool
+	drained chan struct{}
+	stats   EventSenderStats
+}
+
+// NewEventSender starts an EventSender sending events with client. When a
+// spool directory is set, the events spooled by a previous sender are queued
+// first. It returns an error when the number of workers or the queue size is
+// not positive.
+func NewEventSender(client *Client, opts ...EventSenderOptionFunc) (*EventSender, error) {
+	s, err := newEventSender(client, opts...)
+	if err != nil {
+		return nil, err
+	}
+	for i := 0; i < s.workers; i++ {
+		s.wg.Add(1)
+		go s.work()
+	}
+	return s, nil
+}
+
+// newEventSender returns an EventSender without starting its workers.
+func newEventSender(client *Client, opts ...EventSenderOptionFunc) (*EventSender, error) {
+	s := &EventSender{
+		client:    client,
+		sending:   make(map[string]bool),
+		workers:   2,
+		queueSize: 1000,
+		retryPolicy: RetryPolicy{
+			MaxAttempts: 8,
+			MinBackoff:  time.Second,
+			MaxBackoff:  time.Minute,
+		},
+	}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if s.workers < 1 {
+		return nil, fmt.Errorf("pagerduty: event sender needs at least one worker, got %d", s.workers)
+	}
+	if s.queueSize < 1 {
+		return nil, fmt.Errorf("pagerduty: event sender queue size must be positive, got %d", s.queueSize)
+	}
+	s.cond = sync.NewCond(&s.mu)
+	s.ctx, s.cancel = context.WithCancel(context.Background())
+	if s.spoolDir != "" {
+		if err := s.loadSpool(); err != nil {
+			return nil, err
+		}
+	}
+	return s, nil
+}
+
+// Send validates e and queues it. When the last event queued for the same
+// dedup key has the same action, e replaces it instead. It returns
+// ErrEventQueueFull when the queue is full and ErrEventSenderClosed after
+// Close.
+func (s *EventSender) Send(e V2Event) error {
+	if err := e.Validate(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return ErrEventSenderClosed
+	}
+	if e.DedupKey != "" {
+		// Only the last queued event for the key may absorb e: merging into an
+		// earlier one would reorder e before the events queued after it.
+		for i := len(s.queue) - 1; i >= 0; i-- {
+			q := s.queue[i]
+			if q.event.DedupKey != e.DedupKey {
+				continue
+			}
+			if q.event.Action == e.Action {
+				q.event = e
+				s.stats.Coalesced++
+				return s.spool(q)
+			}
+			break
+		}
+	}
+	if len(s.queue) >= s.queueSize {
+		s.stats.Dropped++
+		return ErrEventQueueFull
+	}
+	q := &queuedEvent{event: e}
+	if s.spoolDir != "" {
+		s.seq++
+		q.file = filepath.Join(s.spoolDir, fmt.Sprintf("%020d-%06d%s", time.Now().UnixNano(), s.seq, spoolFileSuffix))
+		if err := s.spool(q); err != nil {
+			return err
+		}
+	}
+	s.queue = append(s.queue, q)
+	s.cond.Signal()
+	return nil
+}
+
+// Flush waits until every queued event has been sent or given up on, or ctx
+// is done.
+func (s *EventSender) Flush(ctx context.Context) error {
+	s.mu.Lock()
+	if len(s.queue) == 0 && s.stats.InFlight == 0 {
+		s.mu.Unlock()
+		return nil
+	}
+	if s.drained == nil {
+		s.drained = make(chan struct{})
+	}
+	drained := s.drained
+	s.mu.Unlock()
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops the sender, interrupting the events being sent. Events not sent
+// yet are left in the spool directory, if any, or dropped. Call Flush first to
+// wait for the queue to be sent.
+func (s *EventSender) Close() error {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return nil
+	}
+	s.closed = true
+	s.cond.Broadcast()
+	s.mu.Unlock()
+	s.cancel()
+	s.wg.Wait()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.spoolDir == "" {
+		s.stats.Dropped += len(s.queue)
+	}
+	s.queue = nil
+	s.notifyDrained()
+	return nil
+}
+
+// Stats returns the current counters of the sender.
+func (s *EventSender) Stats() EventSenderStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	stats := s.stats
+	stats.Queued = len(s.queue)
+	return stats
+}
+
+func (s *EventSender) work() {
+	defer s.wg.Done()
+	for {
+		s.mu.Lock()
+		i := s.next()
+		for i < 0 && !s.closed {
+			s.cond.Wait()
+			i = s.next()
+		}
+		if s.closed {
+			s.mu.Unlock()
+			return
+		}
+		q := s.queue[i]
+		s.queue = append(s.queue[:i], s.queue[i+1:]...)
+		s.stats.InFlight++
+		s.sending[q.event.DedupKey] = true
+		s.mu.Unlock()
+
+		sent, requeue := s.deliver(q)
+
+		s.mu.Lock()
+		s.stats.InFlight--
+		delete(s.sending, q.event.DedupKey)
+		s.cond.Broadcast()
+		switch {
+		case requeue:
+			s.queue = append([]*queuedEvent{q}, s.queue...)
+		case sent:
+			s.stats.Sent++
+			s.unspool(q)
+		default:
+			s.stats.Failed++
+			s.unspool(q)
+		}
+		s.notifyDrained()
+		s.mu.Unlock()
+	}
+}
+
+// next returns the index of the first queued event that can be sent, or -1.
+// Events are held back while another event with the same dedup key is being
+// sent, so they reach PagerDuty in order. It must be called with s.mu held.
+func (s *EventSender) next() int {
+	for i, q := range s.queue {
+		if q.event.DedupKey == "" || !s.sending[q.event.DedupKey] {
+			return i
+		}
+	}
+	return -1
+}
+
+// deliver sends q, retrying it according to the sender's retry policy. The
+// RetryPolicy of the client is not applied on top of it. It reports whether
+// the event was sent, or whether the sender was closed before it could be and
+// it should stay queued.
+func (s *EventSender) deliver(q *queuedEvent) (sent, requeue bool) {
+	p := s.retryPolicy
+	ctx := withoutRetries(s.ctx)
+	for attempt := 1; ; attempt++ {
+		_, err := s.client.ManageEventWithContext(ctx, q.event)
+		if err == nil {
+			return true, false
+		}
+		if s.ctx.Err() != nil {
+			return false, true
+		}
+		if !retriableEventError(err) || attempt >= p.MaxAttempts {
+			return false, false
+		}
+		if sleepContext(s.ctx, p.backoff(attempt)) != nil {
+			return false, true
+		}
+	}
+}
+
+// retriableEventError reports whether sending an event failed for a reason
+// that may go away, as opposed to the event being rejected.
+func retriableEventError(err error) bool {
+	var verr *ValidationError
+	if errors.As(err, &verr) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return true
+}
+
+// notifyDrained wakes up the callers of Flush once nothing is left to send.
+// It must be called with s.mu held.
+func (s *EventSender) notifyDrained() {
+	if s.drained != nil && len(s.queue) == 0 && s.stats.InFlight == 0 {
+		close(s.drained)
+		s.drained = nil
+	}
+}
+
+// spool writes the spooled copy of q, if the sender has a spool directory.
+func (s *EventSender) spool(q *queuedEvent) error {
+	if q.file == "" {
+		return nil
+	}
+	data, err := json.Marshal(q.event)
+	if err != nil {
+		return err
+	}
+	tmp := q.file + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, q.file)
+}
+
+func (s *EventSender) unspool(q *queuedEvent) {
+	if q.file != "" {
+		os.Remove(q.file)
+	}
+}
+
+// loadSpool queues the events left in the spool directory, oldest first.
+func (s *EventSender) loadSpool() error {
+	if err := os.MkdirAll(s.spoolDir, 0700); err != nil {
+		return err
+	}
+	files, err := ioutil.ReadDir(s.spoolDir)
+	if err != nil {
+		return err
+	}
+	var names []string
+	for _, f := range files {
+		if strings.HasSuffix(f.Name(), spoolFileSuffix) {
+			names = append(names, f.Name())
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(s.spoolDir, name)
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		q := &queuedEvent{file: path}
+		if err := json.Unmarshal(data, &q.event); err != nil {
+			return fmt.Errorf("could not decode spooled event %s: %v", path, err)
+		}
+		s.queue = append(s.queue, q)
+	}
+	return nil
+}
@@ -0,0 +1,219 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sync"
+	"testing"
+	"time"
+)
+
+// eventsServer accepts events, failing the first failures requests with a 503,
+// and records the dedup keys of the events it accepted.
+func eventsServer(failures int, keys *[]string) *httptest.Server {
+	var mu sync.Mutex
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if failures > 0 {
+			failures--
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		var e V2Event
+		json.NewDecoder(r.Body).Decode(&e)
+		*keys = append(*keys, e.DedupKey)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status": "success", "dedup_key": "` + e.DedupKey + `"}`))
+	}))
+}
+
+func resolveEvent(key string) V2Event {
+	return V2Event{RoutingKey: "key", Action: EventActionResolve, DedupKey: key}
+}
+
+func TestEventSenderRetriesAndFlushes(t *testing.T) {
+	var keys []string
+	server := eventsServer(2, &keys)
+	defer server.Close()
+	client := NewClient("", WithCustomClient(rewriteHostClient(server.URL)))
+	sender, err := NewEventSender(client, WithSenderWorkers(1), WithSenderRetryPolicy(fastRetryPolicy()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.Close()
+
+	for _, key := range []string{"a", "b", "c"} {
+		if err := sender.Send(resolveEvent(key)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sender.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if stats := sender.Stats(); stats.Sent != 3 || stats.Failed != 0 || stats.Queued != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	if len(keys) != 3 || keys[0] != "a" {
+		t.Errorf("unexpected events sent %v", keys)
+	}
+}
+
+func TestEventSenderIgnoresClientRetries(t *testing.T) {
+	var mu sync.Mutex
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+	client := NewClient("", WithCustomClient(rewriteHostClient(server.URL)), WithRetryPolicy(fastRetryPolicy()))
+	p := fastRetryPolicy()
+	p.MaxAttempts = 3
+	sender, err := NewEventSender(client, WithSenderWorkers(1), WithSenderRetryPolicy(p))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.Close()
+
+	sender.Send(resolveEvent("a"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sender.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestEventSenderCoalescesAndDrops(t *testing.T) {
+	sender, err := newEventSender(NewClient(""), WithSenderQueueSize(2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	sender.Send(resolveEvent("a"))
+	sender.Send(resolveEvent("a"))
+	sender.Send(resolveEvent("b"))
+	if err := sender.Send(resolveEvent("c")); err != ErrEventQueueFull {
+		t.Errorf("expected ErrEventQueueFull, got %v", err)
+	}
+	if stats := sender.Stats(); stats.Queued != 2 || stats.Coalesced != 1 || stats.Dropped != 1 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	sender.Close()
+	if err := sender.Send(resolveEvent("d")); err != ErrEventSenderClosed {
+		t.Errorf("expected ErrEventSenderClosed, got %v", err)
+	}
+	if stats := sender.Stats(); stats.Dropped != 3 {
+		t.Errorf("expected the queued events to be dropped, got %+v", stats)
+	}
+}
+
+func TestEventSenderKeepsActionOrder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	offline, err := newEventSender(NewClient(""), WithSpoolDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	trigger := V2Event{RoutingKey: "key", Action: EventActionTrigger, DedupKey: "k",
+		Payload: &V2Payload{Summary: "Disk full", Source: "db1", Severity: "critical"}}
+	for _, e := range []V2Event{trigger, resolveEvent("k"), trigger} {
+		if err := offline.Send(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if stats := offline.Stats(); stats.Queued != 3 || stats.Coalesced != 0 {
+		t.Errorf("unexpected stats %+v", stats)
+	}
+	offline.Close()
+
+	var mu sync.Mutex
+	var actions []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var e V2Event
+		json.NewDecoder(r.Body).Decode(&e)
+		mu.Lock()
+		actions = append(actions, e.Action)
+		mu.Unlock()
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status": "success", "dedup_key": "k"}`))
+	}))
+	defer server.Close()
+	client := NewClient("", WithCustomClient(rewriteHostClient(server.URL)))
+	sender, err := NewEventSender(client, WithSpoolDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sender.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if len(actions) != 3 || actions[0] != EventActionTrigger || actions[1] != EventActionResolve || actions[2] != EventActionTrigger {
+		t.Errorf("unexpected actions sent %v", actions)
+	}
+}
+
+func TestEventSenderRejectsInvalidOptions(t *testing.T) {
+	for _, opt := range []EventSenderOptionFunc{WithSenderWorkers(0), WithSenderWorkers(-1), WithSenderQueueSize(0)} {
+		if _, err := NewEventSender(NewClient(""), opt); err == nil {
+			t.Error("expected an error")
+		}
+	}
+}
+
+func TestEventSenderSpool(t *testing.T) {
+	dir, err := ioutil.TempDir("", "spool")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	offline, err := newEventSender(NewClient(""), WithSpoolDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	offline.Send(resolveEvent("a"))
+	offline.Send(resolveEvent("b"))
+	offline.Close()
+
+	var keys []string
+	server := eventsServer(0, &keys)
+	defer server.Close()
+	client := NewClient("", WithCustomClient(rewriteHostClient(server.URL)))
+	sender, err := NewEventSender(client, WithSpoolDir(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer sender.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := sender.Flush(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if len(keys) != 2 {
+		t.Errorf("expected the spooled events to be sent, got %v", keys)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("expected an empty spool, found %d files", len(files))
+	}
+}
@@ -0,0 +1,194 @@
+package pagerduty
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+	"unicode/utf8"
+)
+
+const eventV2Path = "/v2/enqueue"
+
+// Event actions of the Events API v2.
+const (
+	EventActionTrigger     = "trigger"
+	EventActionAcknowledge = "acknowledge"
+	EventActionResolve     = "resolve"
+)
+
+// Severities of an Events API v2 payload.
+const (
+	SeverityCritical = "critical"
+	SeverityError    = "error"
+	SeverityWarning  = "warning"
+	SeverityInfo     = "info"
+)
+
+// maxSummaryLength is the longest summary accepted by the events API.
+const maxSummaryLength = 1024
+
+// V2Event is an alert event of the Events API v2.
+type V2Event struct {
+	// RoutingKey is the integration key of the service the event is for.
+	RoutingKey string `json:"routing_key"`
+	// Action is one of EventActionTrigger, EventActionAcknowledge or
+	// EventActionResolve.
+	Action string `json:"event_action"`
+	// DedupKey identifies the alert the event applies to. It is required to
+	// acknowledge or resolve an alert, and generated by PagerDuty when a
+	// trigger event has none.
+	DedupKey  string       `json:"dedup_key,omitempty"`
+	Client    string       `json:"client,omitempty"`
+	ClientURL string       `json:"client_url,omitempty"`
+	Payload   *V2Payload   `json:"payload,omitempty"`
+	Images    []EventImage `json:"images,omitempty"`
+	Links     []EventLink  `json:"links,omitempty"`
+}
+
+// V2Payload describes the problem a trigger event reports.
+type V2Payload struct {
+	// Summary is a brief text summary of the event, at most 1024 characters.
+	Summary string `json:"summary"`
+	// Source is the unique location of the affected system, such as a host.
+	Source string `json:"source"`
+	// Severity is one of SeverityCritical, SeverityError, SeverityWarning or
+	// SeverityInfo.
+	Severity string `json:"severity"`
+	// Timestamp is the time the problem was detected, in RFC 3339 format.
+	Timestamp string      `json:"timestamp,omitempty"`
+	Component string      `json:"component,omitempty"`
+	Group     string      `json:"group,omitempty"`
+	Class     string      `json:"class,omitempty"`
+	Details   interface{} `json:"custom_details,omitempty"`
+}
+
+// EventImage is an image attached to an event.
+type EventImage struct {
+	Src  string `json:"src"`
+	Href string `json:"href,omitempty"`
+	Alt  string `json:"alt,omitempty"`
+}
+
+// EventLink is a link attached to an event.
+type EventLink struct {
+	Href string `json:"href"`
+	Text string `json:"text,omitempty"`
+}
+
+// V2EventResponse is the data returned from the Events API v2.
+type V2EventResponse struct {
+	Status   string `json:"status"`
+	Message  string `json:"message"`
+	DedupKey string `json:"dedup_key"`
+}
+
+// Validate checks that e has every field required by its action. It returns a
+// *ValidationError describing the first problem found.
+func (e V2Event) Validate() error {
+	if e.RoutingKey == "" {
+		return &ValidationError{Field: "routing_key", Reason: "is required"}
+	}
+	switch e.Action {
+	case EventActionTrigger:
+		if e.Payload == nil {
+			return &ValidationError{Field: "payload", Reason: "is required to trigger an alert"}
+		}
+		if err := e.Payload.validate(); err != nil {
+			return err
+		}
+	case EventActionAcknowledge, EventActionResolve:
+		if e.DedupKey == "" {
+			return &ValidationError{Field: "dedup_key", Reason: "is required to " + e.Action + " an alert"}
+		}
+	default:
+		return &ValidationError{Field: "event_action", Reason: "must be trigger, acknowledge or resolve"}
+	}
+	for _, img := range e.Images {
+		if img.Src == "" {
+			return &ValidationError{Field: "images.src", Reason: "is required"}
+		}
+	}
+	return validateLinks(e.Links)
+}
+
+func (p *V2Payload) validate() error {
+	switch {
+	case p.Summary == "":
+		return &ValidationError{Field: "payload.summary", Reason: "is required"}
+	case utf8.RuneCountInString(p.Summary) > maxSummaryLength:
+		return &ValidationError{Field: "payload.summary", Reason: "is longer than 1024 characters"}
+	case p.Source == "":
+		return &ValidationError{Field: "payload.source", Reason: "is required"}
+	}
+	switch p.Severity {
+	case SeverityCritical, SeverityError, SeverityWarning, SeverityInfo:
+	default:
+		return &ValidationError{Field: "payload.severity", Reason: "must be critical, error, warning or info"}
+	}
+	return validateTimestamp("payload.timestamp", p.Timestamp)
+}
+
+func validateLinks(links []EventLink) error {
+	for _, link := range links {
+		if link.Href == "" {
+			return &ValidationError{Field: "links.href", Reason: "is required"}
+		}
+	}
+	return nil
+}
+
+func validateTimestamp(field, ts string) error {
+	if ts == "" {
+		return nil
+	}
+	if _, err := time.Parse(time.RFC3339, ts); err != nil {
+		return &ValidationError{Field: field, Reason: "is not an RFC 3339 timestamp"}
+	}
+	return nil
+}
+
+// ManageEvent sends an alert event to the Events API v2, using the default
+// HTTP client.
+func ManageEvent(e V2Event) (*V2EventResponse, error) {
+	return ManageEventWithContext(context.Background(), e)
+}
+
+// ManageEventWithContext is like ManageEvent but takes a context.
+func ManageEventWithContext(ctx context.Context, e V2Event) (*V2EventResponse, error) {
+	return NewClient("").ManageEventWithContext(ctx, e)
+}
+
+// ManageEvent sends an alert event to the Events API v2 through the client's
+// HTTP client, retry policy and events rate limiter. The event is validated
+// first. Rejected events return an *APIError, matching ErrBadRequest for an
+// invalid event and ErrRateLimited when too many events were sent.
+func (c *Client) ManageEvent(e V2Event) (*V2EventResponse, error) {
+	return c.ManageEventWithContext(context.Background(), e)
+}
+
+// ManageEventWithContext is like ManageEvent but takes a context.
+func (c *Client) ManageEventWithContext(ctx context.Context, e V2Event) (*V2EventResponse, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+	var eventResponse V2EventResponse
+	if err := c.enqueueEvent(ctx, eventV2Path, e, &eventResponse); err != nil {
+		return nil, err
+	}
+	return &eventResponse, nil
+}
+
+// enqueueEvent sends payload to the Events API v2 endpoint at path and decodes
+// the response into v.
+func (c *Client) enqueueEvent(ctx context.Context, path string, payload, v interface{}) error {
+	resp, err := c.postEvent(ctx, path, payload)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= http.StatusMultipleChoices {
+		return newEventAPIError(resp)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
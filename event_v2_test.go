@@ -0,0 +1,101 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// rewriteHostClient sends every request to the server at target, whatever
+// the host it was made for.
+func rewriteHostClient(target string) HTTPClient {
+	u, _ := url.Parse(target)
+	return DoerFunc(func(req *http.Request) (*http.Response, error) {
+		req.URL.Scheme = u.Scheme
+		req.URL.Host = u.Host
+		return http.DefaultClient.Do(req)
+	})
+}
+
+func TestV2EventValidate(t *testing.T) {
+	payload := &V2Payload{Summary: "Disk full", Source: "db-1", Severity: SeverityCritical}
+	cases := []struct {
+		event V2Event
+		field string
+	}{
+		{V2Event{RoutingKey: "key", Action: EventActionTrigger, Payload: payload}, ""},
+		{V2Event{RoutingKey: "key", Action: EventActionResolve, DedupKey: "disk"}, ""},
+		{V2Event{Action: EventActionTrigger, Payload: payload}, "routing_key"},
+		{V2Event{RoutingKey: "key", Action: "page"}, "event_action"},
+		{V2Event{RoutingKey: "key", Action: EventActionTrigger}, "payload"},
+		{V2Event{RoutingKey: "key", Action: EventActionAcknowledge}, "dedup_key"},
+		{V2Event{RoutingKey: "key", Action: EventActionTrigger, Payload: &V2Payload{Summary: "Disk full", Source: "db-1", Severity: "fatal"}}, "payload.severity"},
+		{V2Event{RoutingKey: "key", Action: EventActionTrigger, Payload: &V2Payload{Summary: "Disk full", Source: "db-1", Severity: SeverityInfo, Timestamp: "yesterday"}}, "payload.timestamp"},
+		{V2Event{RoutingKey: "key", Action: EventActionTrigger, Payload: payload, Links: []EventLink{{Text: "runbook"}}}, "links.href"},
+		{V2Event{RoutingKey: "key", Action: EventActionTrigger, Payload: &V2Payload{Summary: strings.Repeat("é", 1024), Source: "db-1", Severity: SeverityInfo}}, ""},
+		{V2Event{RoutingKey: "key", Action: EventActionTrigger, Payload: &V2Payload{Summary: strings.Repeat("é", 1025), Source: "db-1", Severity: SeverityInfo}}, "payload.summary"},
+	}
+	for _, c := range cases {
+		err := c.event.Validate()
+		var verr *ValidationError
+		switch {
+		case c.field == "" && err != nil:
+			t.Errorf("expected %+v to be valid, got %v", c.event, err)
+		case c.field != "" && (!errors.As(err, &verr) || verr.Field != c.field):
+			t.Errorf("expected an invalid %s, got %v", c.field, err)
+		}
+	}
+}
+
+func TestManageEvent(t *testing.T) {
+	var received V2Event
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v2/enqueue" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		json.NewDecoder(r.Body).Decode(&received)
+		w.WriteHeader(http.StatusAccepted)
+		w.Write([]byte(`{"status": "success", "message": "Event processed", "dedup_key": "srv01/HTTP"}`))
+	}))
+	defer server.Close()
+	client := NewClient("", WithCustomClient(rewriteHostClient(server.URL)))
+
+	resp, err := client.ManageEvent(V2Event{
+		RoutingKey: "key",
+		Action:     EventActionTrigger,
+		Payload:    &V2Payload{Summary: "HTTP down", Source: "srv01", Severity: SeverityError, Details: map[string]string{"ping": "timeout"}},
+		Links:      []EventLink{{Href: "https://example.com/runbook", Text: "Runbook"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.DedupKey != "srv01/HTTP" {
+		t.Errorf("unexpected dedup key %q", resp.DedupKey)
+	}
+	if received.Payload == nil || received.Payload.Source != "srv01" || len(received.Links) != 1 {
+		t.Errorf("unexpected event sent %+v", received)
+	}
+}
+
+func TestManageEventErrors(t *testing.T) {
+	event := V2Event{RoutingKey: "key", Action: EventActionResolve, DedupKey: "disk"}
+	cases := []struct {
+		status int
+		body   string
+		check  func(error) bool
+	}{
+		{http.StatusBadRequest, `{"status": "invalid event", "message": "Event object is invalid", "errors": ["Length of 'routing_key' is incorrect"]}`, IsBadRequest},
+		{http.StatusTooManyRequests, `{"status": "throttle event", "message": "Requests for this service are arriving too quickly"}`, IsRateLimited},
+	}
+	for _, c := range cases {
+		client := NewClient("", WithCustomClient(statusHTTPClient{status: c.status, body: c.body}))
+		_, err := client.ManageEvent(event)
+		if !c.check(err) {
+			t.Errorf("status %d: unexpected error %v", c.status, err)
+		}
+	}
+}
@@ -1,5 +1,7 @@
 package pagerduty
 
+import "context"
+
 type ExtensionSchema struct {
 	APIObject
 }
@@ -71,7 +73,12 @@ func ExtensionWithObjects(r ...APIObject) ExtensionOptFunc{
 
 
 func (c *Client) GetExtension(id string, opts ...ResourceRequestOptionFunc) (*Extension, error) {
-	res, err := c.GetResource(ExtensionResourceType, id, opts...)
+	return c.GetExtensionWithContext(context.Background(), id, opts...)
+}
+
+// GetExtensionWithContext is like GetExtension but takes a context.
+func (c *Client) GetExtensionWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*Extension, error) {
+	res, err := c.GetResourceWithContext(ctx, ExtensionResourceType, id, opts...)
 	if err != nil {
 		return nil, err
 	}
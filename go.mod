@@ -1,12 +1,10 @@
 module github.com/kylie-a/go-pagerduty
 
 require (
-	github.com/PagerDuty/go-pagerduty v0.0.0-20181104233218-fe8f9c4593d0
 	github.com/google/go-querystring v1.0.0
 	github.com/im-auld/go-pagerduty v0.0.0-20181107210635-91cbb123079b
 	github.com/mitchellh/cli v1.0.0
 	github.com/mitchellh/go-homedir v1.1.0
-	github.com/pkg/errors v0.8.1
 	github.com/sirupsen/logrus v1.3.0
 	gopkg.in/yaml.v2 v2.2.2
 )
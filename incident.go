@@ -1,7 +1,10 @@
 package pagerduty
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"net/http"
 
@@ -22,14 +25,28 @@ type PendingAction struct {
 
 // Assignment is the data structure for an assignment of an incident
 type Assignment struct {
-	At       string
-	Assignee APIObject
+	At       string    `json:"at,omitempty"`
+	Assignee APIObject `json:"assignee"`
+}
+
+// ConferenceBridge is the dial-in number and URL of the conference call
+// responders of an incident join.
+type ConferenceBridge struct {
+	ConferenceNumber string `json:"conference_number,omitempty"`
+	ConferenceURL    string `json:"conference_url,omitempty"`
+}
+
+// IncidentBody holds additional details about a new incident.
+type IncidentBody struct {
+	Type    string `json:"type"`
+	Details string `json:"details,omitempty"`
 }
 
 // Incident is a normalized, de-duplicated event generated by a PagerDuty integration.
 type Incident struct {
 	APIObject
 	IncidentNumber       uint              `json:"incident_number,omitempty"`
+	Title                string            `json:"title,omitempty"`
 	CreatedAt            string            `json:"created_at,omitempty"`
 	PendingActions       []PendingAction   `json:"pending_actions,omitempty"`
 	IncidentKey          string            `json:"incident_key,omitempty"`
@@ -42,7 +59,13 @@ type Incident struct {
 	EscalationPolicy     APIObject         `json:"escalation_policy,omitempty"`
 	Teams                []APIObject       `json:"teams,omitempty"`
 	Urgency              string            `json:"urgency,omitempty"`
+	Priority             *Priority         `json:"priority,omitempty"`
 	Status               string            `json:"status,omitempty"`
+	ConferenceBridge     *ConferenceBridge `json:"conference_bridge,omitempty"`
+	// IncidentResponders are the users requested to respond to the incident,
+	// with the state of their request.
+	IncidentResponders []IncidentResponderState `json:"incident_responders,omitempty"`
+	ResponderRequests  []ResponderRequest       `json:"responder_requests,omitempty"`
 }
 
 type IncidentResponse struct {
@@ -65,12 +88,12 @@ type ListIncidentsResponse struct {
 	Incidents []Incident `json:"incidents,omitempty"`
 }
 
-type ListIncidentAlertsResponse struct {
-	Alerts []Alert `json:"alerts"`
-	Limit  int     `json:"limit"`
-	Offset int     `json:"offset"`
-	More   bool    `json:"more"`
-	Total  *int     `json:"total"`
+func (list ListIncidentsResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Incidents))
+	for i, r := range list.Incidents {
+		resources[i] = r
+	}
+	return resources
 }
 
 // ListIncidentsOptions is the structure used when passing parameters to the ListIncident API endpoint.
@@ -92,7 +115,12 @@ type ListIncidentsOptions struct {
 
 // ListIncidents lists existing incidents.
 func (c *Client) ListIncidents(opts ...ResourceRequestOptionFunc) (*ListIncidentsResponse, error) {
-	resp, err := c.ListResources(IncidentResourceType, opts...)
+	return c.ListIncidentsWithContext(context.Background(), opts...)
+}
+
+// ListIncidentsWithContext is like ListIncidents but takes a context.
+func (c *Client) ListIncidentsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListIncidentsResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, IncidentResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,18 +128,211 @@ func (c *Client) ListIncidents(opts ...ResourceRequestOptionFunc) (*ListIncident
 	return &result, deserialize(resp, &result)
 }
 
-// TODO: Update for multiple resources
-// ManageIncidents acknowledges, resolves, escalates, or reassigns one or more incidents.
-func (c *Client) ManageIncidents(from string, incidents []Incident) error {
+// HasPriority reports whether the incident has one of the given priorities,
+// identified by ID or by name such as "P1".
+func (i Incident) HasPriority(priorities ...string) bool {
+	if i.Priority == nil {
+		return false
+	}
+	for _, p := range priorities {
+		if i.Priority.Matches(p) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrTooManyIncidents is returned by ListIncidentsByPriority when the time
+// window holds more incidents than it reads. The incidents read so far are
+// returned with it.
+var ErrTooManyIncidents = errors.New("pagerduty: too many incidents in the time window, narrow it")
+
+const (
+	// priorityPageSize and priorityMaxPages bound the incidents
+	// ListIncidentsByPriority reads to filter on the client.
+	priorityPageSize = 100
+	priorityMaxPages = 10
+)
+
+// ListIncidentsByPriority lists the incidents created between since and until
+// and matching opts that have one of the given priorities, identified by ID or
+// by name such as "P1". The API cannot filter incidents by priority, so the
+// incidents are filtered by the client. At most 1000 incidents are read;
+// ErrTooManyIncidents is returned when the window holds more.
+func (c *Client) ListIncidentsByPriority(priorities []string, since, until time.Time, opts ...ResourceRequestOptionFunc) ([]Incident, error) {
+	return c.ListIncidentsByPriorityWithContext(context.Background(), priorities, since, until, opts...)
+}
+
+// ListIncidentsByPriorityWithContext is like ListIncidentsByPriority but takes
+// a context.
+func (c *Client) ListIncidentsByPriorityWithContext(ctx context.Context, priorities []string, since, until time.Time, opts ...ResourceRequestOptionFunc) ([]Incident, error) {
+	switch {
+	case since.IsZero():
+		return nil, &ValidationError{Field: "since", Reason: "is required"}
+	case until.IsZero():
+		return nil, &ValidationError{Field: "until", Reason: "is required"}
+	case !until.After(since):
+		return nil, &ValidationError{Field: "until", Reason: "must be after since"}
+	}
+	opts = append(opts[:len(opts):len(opts)], func(r *http.Request) error {
+		if err := replaceQueryParam("since", since.Format(time.RFC3339), r); err != nil {
+			return err
+		}
+		return replaceQueryParam("until", until.Format(time.RFC3339), r)
+	})
+	pages := 0
+	it := newIterator(ctx, func(ctx context.Context, opts ...ResourceRequestOptionFunc) (ResourceList, error) {
+		if pages == priorityMaxPages {
+			return nil, ErrTooManyIncidents
+		}
+		pages++
+		return c.listPage(ctx, IncidentResourceType, opts...)
+	}, opts)
+	it.PageSize = priorityPageSize
+	var incidents []Incident
+	for it.Next() {
+		if incident := it.Resource().(Incident); incident.HasPriority(priorities...) {
+			incidents = append(incidents, incident)
+		}
+	}
+	return incidents, it.Err()
+}
+
+// ManageIncidents acknowledges, resolves, escalates, or reassigns one or more
+// incidents, on behalf of the user whose email address is from. It returns the
+// updated incidents.
+func (c *Client) ManageIncidents(from string, incidents []Incident) (*ListIncidentsResponse, error) {
+	return c.ManageIncidentsWithContext(context.Background(), from, incidents)
+}
+
+// ManageIncidentsWithContext is like ManageIncidents but takes a context.
+func (c *Client) ManageIncidentsWithContext(ctx context.Context, from string, incidents []Incident) (*ListIncidentsResponse, error) {
 	r := make(map[string][]Incident)
 	r["incidents"] = incidents
-	_, e := c.put("/incidents", r, WithHeader("From", from))
-	return e
+	resp, err := c.put(ctx, "/incidents", r, WithHeader("From", from))
+	if err != nil {
+		return nil, err
+	}
+	var result ListIncidentsResponse
+	return &result, deserialize(resp, &result)
+}
+
+// CreateIncidentOptions is the data structure used when calling the
+// CreateIncident API endpoint. The incident is assigned to Assignments, or
+// else escalated through EscalationPolicy, or else through the escalation
+// policy of Service.
+type CreateIncidentOptions struct {
+	Title            string            `json:"title"`
+	Service          APIReference      `json:"service"`
+	Priority         *APIReference     `json:"priority,omitempty"`
+	Urgency          string            `json:"urgency,omitempty"`
+	Body             *IncidentBody     `json:"body,omitempty"`
+	IncidentKey      string            `json:"incident_key,omitempty"`
+	Assignments      []Assignment      `json:"assignments,omitempty"`
+	EscalationPolicy *APIReference     `json:"escalation_policy,omitempty"`
+	ConferenceBridge *ConferenceBridge `json:"conference_bridge,omitempty"`
+}
+
+// Validate checks that the options have the fields PagerDuty requires.
+func (o CreateIncidentOptions) Validate() error {
+	if o.Title == "" {
+		return &ValidationError{Field: "title", Reason: "is required"}
+	}
+	if o.Service.ID == "" {
+		return &ValidationError{Field: "service.id", Reason: "is required"}
+	}
+	if len(o.Assignments) > 0 && o.EscalationPolicy != nil {
+		return &ValidationError{Field: "assignments", Reason: "cannot be set along with escalation_policy"}
+	}
+	return nil
+}
+
+// CreateIncident creates an incident on behalf of the user whose email address
+// is from.
+func (c *Client) CreateIncident(from string, o CreateIncidentOptions) (*Incident, error) {
+	return c.CreateIncidentWithContext(context.Background(), from, o)
+}
+
+// CreateIncidentWithContext is like CreateIncident but takes a context.
+func (c *Client) CreateIncidentWithContext(ctx context.Context, from string, o CreateIncidentOptions) (*Incident, error) {
+	if err := o.Validate(); err != nil {
+		return nil, err
+	}
+	if o.Service.Type == "" {
+		o.Service.Type = "service_reference"
+	}
+	if o.Body != nil && o.Body.Type == "" {
+		o.Body.Type = "incident_body"
+	}
+	data := map[string]interface{}{
+		"incident": struct {
+			Type string `json:"type"`
+			CreateIncidentOptions
+		}{"incident", o},
+	}
+	resp, err := c.post(ctx, "/incidents", data, WithHeader("From", from))
+	return getIncidentFromResponse(resp, err)
+}
+
+// UpdateIncidentOptions is the data structure used when calling the
+// UpdateIncident API endpoint. Only the fields set are changed.
+type UpdateIncidentOptions struct {
+	Title            string            `json:"title,omitempty"`
+	Status           string            `json:"status,omitempty"`
+	Resolution       string            `json:"resolution,omitempty"`
+	Urgency          string            `json:"urgency,omitempty"`
+	EscalationLevel  uint              `json:"escalation_level,omitempty"`
+	Priority         *APIReference     `json:"priority,omitempty"`
+	EscalationPolicy *APIReference     `json:"escalation_policy,omitempty"`
+	Assignments      []Assignment      `json:"assignments,omitempty"`
+	ConferenceBridge *ConferenceBridge `json:"conference_bridge,omitempty"`
+}
+
+// UpdateIncident updates an incident on behalf of the user whose email address
+// is from.
+func (c *Client) UpdateIncident(from, id string, o UpdateIncidentOptions) (*Incident, error) {
+	return c.UpdateIncidentWithContext(context.Background(), from, id, o)
+}
+
+// UpdateIncidentWithContext is like UpdateIncident but takes a context.
+func (c *Client) UpdateIncidentWithContext(ctx context.Context, from, id string, o UpdateIncidentOptions) (*Incident, error) {
+	data := map[string]interface{}{
+		"incident": struct {
+			Type string `json:"type"`
+			UpdateIncidentOptions
+		}{"incident_reference", o},
+	}
+	resp, err := c.put(ctx, "/incidents/"+id, data, WithHeader("From", from))
+	return getIncidentFromResponse(resp, err)
+}
+
+// MergeIncidents merges the incidents sourceIDs into the incident id, on
+// behalf of the user whose email address is from. The alerts of the source
+// incidents are moved to the target incident and the source incidents are
+// resolved. It returns the target incident.
+func (c *Client) MergeIncidents(from, id string, sourceIDs []string) (*Incident, error) {
+	return c.MergeIncidentsWithContext(context.Background(), from, id, sourceIDs)
+}
+
+// MergeIncidentsWithContext is like MergeIncidents but takes a context.
+func (c *Client) MergeIncidentsWithContext(ctx context.Context, from, id string, sourceIDs []string) (*Incident, error) {
+	sources := make([]APIReference, len(sourceIDs))
+	for i, sourceID := range sourceIDs {
+		sources[i] = APIReference{ID: sourceID, Type: "incident_reference"}
+	}
+	data := map[string][]APIReference{"source_incidents": sources}
+	resp, err := c.put(ctx, "/incidents/"+id+"/merge", data, WithHeader("From", from))
+	return getIncidentFromResponse(resp, err)
 }
 
 // GetIncident shows detailed information about an incident.
 func (c *Client) GetIncident(id string, opts ...ResourceRequestOptionFunc) (*Incident, error) {
-	res, err := c.GetResource(IncidentResourceType, id, opts...)
+	return c.GetIncidentWithContext(context.Background(), id, opts...)
+}
+
+// GetIncidentWithContext is like GetIncident but takes a context.
+func (c *Client) GetIncidentWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*Incident, error) {
+	res, err := c.GetResourceWithContext(ctx, IncidentResourceType, id, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -129,7 +350,12 @@ type IncidentNote struct {
 
 // ListIncidentNotes lists existing notes for the specified incident.
 func (c *Client) ListIncidentNotes(id string) ([]IncidentNote, error) {
-	resp, err := c.get("/incidents/" + id + "/notes")
+	return c.ListIncidentNotesWithContext(context.Background(), id)
+}
+
+// ListIncidentNotesWithContext is like ListIncidentNotes but takes a context.
+func (c *Client) ListIncidentNotesWithContext(ctx context.Context, id string) ([]IncidentNote, error) {
+	resp, err := c.get(ctx, "/incidents/"+id+"/notes")
 	if err != nil {
 		return nil, err
 	}
@@ -146,20 +372,178 @@ func (c *Client) ListIncidentNotes(id string) ([]IncidentNote, error) {
 
 // CreateIncidentNote creates a new note for the specified incident.
 func (c *Client) CreateIncidentNote(id string, note IncidentNote) error {
+	return c.CreateIncidentNoteWithContext(context.Background(), id, note)
+}
+
+// CreateIncidentNoteWithContext is like CreateIncidentNote but takes a context.
+func (c *Client) CreateIncidentNoteWithContext(ctx context.Context, id string, note IncidentNote) error {
 	data := make(map[string]IncidentNote)
 	data["note"] = note
-	_, err := c.post("/incidents/"+id+"/notes", data)
+	_, err := c.post(ctx, "/incidents/"+id+"/notes", data)
 	return err
 }
 
 // SnoozeIncident sets an incident to not alert for a specified period of time.
 func (c *Client) SnoozeIncident(id string, duration uint) error {
+	return c.SnoozeIncidentWithContext(context.Background(), id, duration)
+}
+
+// SnoozeIncidentWithContext is like SnoozeIncident but takes a context.
+func (c *Client) SnoozeIncidentWithContext(ctx context.Context, id string, duration uint) error {
 	data := make(map[string]uint)
 	data["duration"] = duration
-	_, err := c.post("/incidents/"+id+"/snooze", data)
+	_, err := c.post(ctx, "/incidents/"+id+"/snooze", data)
 	return err
 }
 
+// Types of responder request targets.
+const (
+	ResponderTargetUser             = "user_reference"
+	ResponderTargetEscalationPolicy = "escalation_policy_reference"
+)
+
+// IncidentResponderState is the state of the request made to a user to
+// respond to an incident: pending, joined or declined.
+type IncidentResponderState struct {
+	State       string    `json:"state"`
+	User        APIObject `json:"user"`
+	Incident    APIObject `json:"incident"`
+	UpdatedAt   string    `json:"updated_at,omitempty"`
+	Message     string    `json:"message,omitempty"`
+	Requester   APIObject `json:"requester"`
+	RequestedAt string    `json:"requested_at,omitempty"`
+}
+
+// ResponderRequestTarget is a user or escalation policy requested to respond
+// to an incident, with the state of the request for each user it reached.
+type ResponderRequestTarget struct {
+	APIObject
+	Responders []IncidentResponderState `json:"incident_responders,omitempty"`
+}
+
+// ResponderRequestTargetWrapper wraps a ResponderRequestTarget as the API
+// expects it.
+type ResponderRequestTargetWrapper struct {
+	Target ResponderRequestTarget `json:"responder_request_target"`
+}
+
+// ResponderRequest is a request for additional responders to an incident.
+type ResponderRequest struct {
+	Incident    APIObject                       `json:"incident"`
+	Requester   APIObject                       `json:"requester"`
+	RequestedAt string                          `json:"requested_at,omitempty"`
+	Message     string                          `json:"message,omitempty"`
+	Targets     []ResponderRequestTargetWrapper `json:"responder_request_targets"`
+}
+
+// ResponderRequestOptions is the data structure used when calling the
+// CreateResponderRequest API endpoint.
+type ResponderRequestOptions struct {
+	// RequesterID is the ID of the user making the request.
+	RequesterID string
+	Message     string
+	// Targets are the users and escalation policies requested to respond,
+	// with a Type of ResponderTargetUser or ResponderTargetEscalationPolicy.
+	Targets []APIReference
+}
+
+// CreateResponderRequest requests users or escalation policies to respond to
+// the incident id, on behalf of the user whose email address is from.
+func (c *Client) CreateResponderRequest(from, id string, o ResponderRequestOptions) (*ResponderRequest, error) {
+	return c.CreateResponderRequestWithContext(context.Background(), from, id, o)
+}
+
+// CreateResponderRequestWithContext is like CreateResponderRequest but takes a
+// context.
+func (c *Client) CreateResponderRequestWithContext(ctx context.Context, from, id string, o ResponderRequestOptions) (*ResponderRequest, error) {
+	if o.RequesterID == "" {
+		return nil, &ValidationError{Field: "requester_id", Reason: "is required"}
+	}
+	if len(o.Targets) == 0 {
+		return nil, &ValidationError{Field: "responder_request_targets", Reason: "is required"}
+	}
+	targets := make([]ResponderRequestTargetWrapper, len(o.Targets))
+	for i, t := range o.Targets {
+		targets[i].Target.ID = t.ID
+		targets[i].Target.Type = APIResourceType(t.Type)
+	}
+	data := map[string]interface{}{
+		"requester_id":              o.RequesterID,
+		"message":                   o.Message,
+		"responder_request_targets": targets,
+	}
+	resp, err := c.post(ctx, "/incidents/"+id+"/responder_requests", data, WithHeader("From", from))
+	if err != nil {
+		return nil, err
+	}
+	var rr ResponderRequest
+	return &rr, deserializeField(resp, "responder_request", &rr)
+}
+
+// ListIncidentResponders lists the users requested to respond to an incident,
+// with the state of their request.
+func (c *Client) ListIncidentResponders(id string) ([]IncidentResponderState, error) {
+	return c.ListIncidentRespondersWithContext(context.Background(), id)
+}
+
+// ListIncidentRespondersWithContext is like ListIncidentResponders but takes a
+// context.
+func (c *Client) ListIncidentRespondersWithContext(ctx context.Context, id string) ([]IncidentResponderState, error) {
+	incident, err := c.GetIncidentWithContext(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return incident.IncidentResponders, nil
+}
+
+// IncidentStatusUpdate is a message sent to the stakeholders of an incident.
+type IncidentStatusUpdate struct {
+	ID        string    `json:"id,omitempty"`
+	Message   string    `json:"message,omitempty"`
+	CreatedAt string    `json:"created_at,omitempty"`
+	Sender    APIObject `json:"sender,omitempty"`
+}
+
+// CreateIncidentStatusUpdate sends a status update about an incident to its
+// stakeholders, on behalf of the user whose email address is from.
+func (c *Client) CreateIncidentStatusUpdate(from, id, message string) (*IncidentStatusUpdate, error) {
+	return c.CreateIncidentStatusUpdateWithContext(context.Background(), from, id, message)
+}
+
+// CreateIncidentStatusUpdateWithContext is like CreateIncidentStatusUpdate but
+// takes a context.
+func (c *Client) CreateIncidentStatusUpdateWithContext(ctx context.Context, from, id, message string) (*IncidentStatusUpdate, error) {
+	if message == "" {
+		return nil, &ValidationError{Field: "message", Reason: "is required"}
+	}
+	data := map[string]string{"message": message}
+	resp, err := c.post(ctx, "/incidents/"+id+"/status_updates", data, WithHeader("From", from))
+	if err != nil {
+		return nil, err
+	}
+	var update IncidentStatusUpdate
+	return &update, deserializeField(resp, "status_update", &update)
+}
+
+// ListIncidentStatusUpdates lists the status updates sent about an incident.
+func (c *Client) ListIncidentStatusUpdates(id string) ([]IncidentStatusUpdate, error) {
+	return c.ListIncidentStatusUpdatesWithContext(context.Background(), id)
+}
+
+// ListIncidentStatusUpdatesWithContext is like ListIncidentStatusUpdates but
+// takes a context.
+func (c *Client) ListIncidentStatusUpdatesWithContext(ctx context.Context, id string) ([]IncidentStatusUpdate, error) {
+	resp, err := c.get(ctx, "/incidents/"+id+"/status_updates")
+	if err != nil {
+		return nil, err
+	}
+	var updates []IncidentStatusUpdate
+	if err := deserializeField(resp, "status_updates", &updates); err != nil {
+		return nil, err
+	}
+	return updates, nil
+}
+
 // ListIncidentLogEntriesResponse is the response structure when calling the ListIncidentLogEntries API endpoint.
 type ListIncidentLogEntriesResponse struct {
 	APIListObject
@@ -176,11 +560,16 @@ type ListIncidentLogEntriesOptions struct {
 
 // ListIncidentLogEntries lists existing log entries for the specified incident.
 func (c *Client) ListIncidentLogEntries(id string, o ListIncidentLogEntriesOptions) (*ListIncidentLogEntriesResponse, error) {
+	return c.ListIncidentLogEntriesWithContext(context.Background(), id, o)
+}
+
+// ListIncidentLogEntriesWithContext is like ListIncidentLogEntries but takes a context.
+func (c *Client) ListIncidentLogEntriesWithContext(ctx context.Context, id string, o ListIncidentLogEntriesOptions) (*ListIncidentLogEntriesResponse, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get("/incidents/" + id + "/log_entries?" + v.Encode())
+	resp, err := c.get(ctx, "/incidents/"+id+"/log_entries?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -188,14 +577,15 @@ func (c *Client) ListIncidentLogEntries(id string, o ListIncidentLogEntriesOptio
 	return &result, deserialize(resp, &result)
 }
 
-func (c *Client) ListAlertsForIncident(id string, opts ...ResourceRequestOptionFunc) (*ListIncidentAlertsResponse, error) {
-	resp, err := c.get("/incidents/" + id + "/alerts")
+func getIncidentFromResponse(resp *http.Response, err error) (*Incident, error) {
 	if err != nil {
 		return nil, err
 	}
-	var result *ListIncidentAlertsResponse
-	if err := deserialize(resp, &result); err != nil {
+	defer resp.Body.Close()
+	res, err := NewIncidentResponse(resp).GetResource()
+	if err != nil {
 		return nil, err
 	}
-	return result, nil
+	obj := res.(Incident)
+	return &obj, nil
 }
@@ -0,0 +1,131 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestIncidentWrites(t *testing.T) {
+	var requests []string
+	var bodies []map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path+" "+r.Header.Get("From"))
+		var body map[string]json.RawMessage
+		json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		switch r.Method + " " + r.URL.Path {
+		case "POST /incidents":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"incident": {"id": "PINC1", "type": "incident", "title": "Disk full", "incident_number": 7}}`))
+		case "PUT /incidents":
+			w.Write([]byte(`{"incidents": [{"id": "PINC1", "type": "incident", "status": "acknowledged"}]}`))
+		case "PUT /incidents/PINC1":
+			w.Write([]byte(`{"incident": {"id": "PINC1", "type": "incident", "status": "resolved"}}`))
+		case "PUT /incidents/PINC1/merge":
+			w.Write([]byte(`{"incident": {"id": "PINC1", "type": "incident", "status": "triggered"}}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	var verr *ValidationError
+	if _, err := client.CreateIncident("ops@example.com", CreateIncidentOptions{Title: "Disk full"}); !errors.As(err, &verr) || verr.Field != "service.id" {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	incident, err := client.CreateIncident("ops@example.com", CreateIncidentOptions{
+		Title:            "Disk full",
+		Service:          APIReference{ID: "PSVC1"},
+		Urgency:          "high",
+		Body:             &IncidentBody{Details: "/var is 100% full"},
+		ConferenceBridge: &ConferenceBridge{ConferenceURL: "https://example.com/bridge"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if incident.ID != "PINC1" || incident.Title != "Disk full" || incident.IncidentNumber != 7 {
+		t.Errorf("unexpected incident %+v", incident)
+	}
+	var sent map[string]interface{}
+	json.Unmarshal(bodies[0]["incident"], &sent)
+	if sent["type"] != "incident" || sent["title"] != "Disk full" ||
+		sent["service"].(map[string]interface{})["type"] != "service_reference" ||
+		sent["body"].(map[string]interface{})["type"] != "incident_body" {
+		t.Errorf("unexpected incident sent %v", sent)
+	}
+
+	list, err := client.ManageIncidents("ops@example.com", []Incident{{APIObject: APIObject{ID: "PINC1", Type: "incident_reference"}, Status: "acknowledged"}})
+	if err != nil || len(list.Incidents) != 1 || list.Incidents[0].Status != "acknowledged" {
+		t.Errorf("unexpected managed incidents %+v: %v", list, err)
+	}
+	if incident, err := client.UpdateIncident("ops@example.com", "PINC1", UpdateIncidentOptions{Status: "resolved", Resolution: "Cleaned up"}); err != nil || incident.Status != "resolved" {
+		t.Errorf("unexpected updated incident %+v: %v", incident, err)
+	}
+	if incident, err := client.MergeIncidents("ops@example.com", "PINC1", []string{"PINC2", "PINC3"}); err != nil || incident.ID != "PINC1" {
+		t.Errorf("unexpected merged incident %+v: %v", incident, err)
+	}
+	if string(bodies[3]["source_incidents"]) != `[{"id":"PINC2","type":"incident_reference"},{"id":"PINC3","type":"incident_reference"}]` {
+		t.Errorf("unexpected merge request %s", bodies[3]["source_incidents"])
+	}
+	for _, r := range requests {
+		if !strings.HasSuffix(r, " ops@example.com") {
+			t.Errorf("request without From header: %s", r)
+		}
+	}
+}
+
+func TestIncidentRespondersAndStatusUpdates(t *testing.T) {
+	var requested map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method + " " + r.URL.Path {
+		case "POST /incidents/PINC1/responder_requests":
+			json.NewDecoder(r.Body).Decode(&requested)
+			w.Write([]byte(`{"responder_request": {"incident": {"id": "PINC1"}, "requester": {"id": "PUSR1"}, "message": "Need the DBA",
+				"responder_request_targets": [{"responder_request_target": {"id": "PUSR2", "type": "user",
+					"incident_responders": [{"state": "pending", "user": {"id": "PUSR2"}}]}}]}}`))
+		case "GET /incidents/PINC1":
+			w.Write([]byte(`{"incident": {"id": "PINC1", "type": "incident", "incident_responders": [{"state": "joined", "user": {"id": "PUSR2"}}]}}`))
+		case "POST /incidents/PINC1/status_updates":
+			w.Write([]byte(`{"status_update": {"id": "PSU1", "message": "Failing over"}}`))
+		case "GET /incidents/PINC1/status_updates":
+			w.Write([]byte(`{"status_updates": [{"id": "PSU1", "message": "Failing over"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	rr, err := client.CreateResponderRequest("ops@example.com", "PINC1", ResponderRequestOptions{
+		RequesterID: "PUSR1",
+		Message:     "Need the DBA",
+		Targets:     []APIReference{{ID: "PUSR2", Type: ResponderTargetUser}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rr.Targets) != 1 || rr.Targets[0].Target.Responders[0].State != "pending" {
+		t.Errorf("unexpected responder request %+v", rr)
+	}
+	if string(requested["responder_request_targets"]) != `[{"responder_request_target":{"id":"PUSR2","type":"user_reference"}}]` {
+		t.Errorf("unexpected targets sent %s", requested["responder_request_targets"])
+	}
+	if responders, err := client.ListIncidentResponders("PINC1"); err != nil || len(responders) != 1 || responders[0].State != "joined" {
+		t.Errorf("unexpected responders %+v: %v", responders, err)
+	}
+
+	if _, err := client.CreateIncidentStatusUpdate("ops@example.com", "PINC1", ""); err == nil {
+		t.Error("expected an empty status update to be rejected")
+	}
+	if update, err := client.CreateIncidentStatusUpdate("ops@example.com", "PINC1", "Failing over"); err != nil || update.ID != "PSU1" {
+		t.Errorf("unexpected status update %+v: %v", update, err)
+	}
+	if updates, err := client.ListIncidentStatusUpdates("PINC1"); err != nil || len(updates) != 1 {
+		t.Errorf("unexpected status updates %+v: %v", updates, err)
+	}
+}
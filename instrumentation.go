@@ -0,0 +1,160 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// CallResult describes how an API call ended.
+type CallResult struct {
+	// StatusCode is the status of the response, or 0 when no response was
+	// received.
+	StatusCode int
+	// Retries is the number of attempts made after the first one.
+	Retries int
+	// Latency is the time spent on the call, retries included.
+	Latency time.Duration
+	// Err is the transport error, if any. Responses with an error status are
+	// reported through StatusCode.
+	Err error
+}
+
+// Failed reports whether the call failed, either with a transport error or an
+// error status.
+func (r CallResult) Failed() bool {
+	return r.Err != nil || r.StatusCode >= http.StatusBadRequest
+}
+
+// Span traces a single API call.
+type Span interface {
+	// End is called once the call is over.
+	End(CallResult)
+}
+
+// Instrumentation receives traces and metrics for every call made by the
+// client, so they can be forwarded to a tracer or a metrics backend.
+type Instrumentation interface {
+	// StartSpan is called before a call is made. The returned context is used
+	// for the request, so a span stored in it is the parent of anything the
+	// HTTP client traces.
+	StartSpan(ctx context.Context, info RequestInfo) (context.Context, Span)
+	// RecordCall is called after every call, to update latency and error
+	// metrics.
+	RecordCall(info RequestInfo, result CallResult)
+}
+
+// WithInstrumentation makes the client report its calls to i.
+func WithInstrumentation(i Instrumentation) NewClientOptionFunc {
+	return func(client *Client) {
+		client.instrumentation = i
+	}
+}
+
+// NoopInstrumentation discards traces and metrics. It is the default
+// Instrumentation of a Client.
+type NoopInstrumentation struct{}
+
+type noopSpan struct{}
+
+func (noopSpan) End(CallResult) {}
+
+// StartSpan returns ctx unchanged and a span doing nothing.
+func (NoopInstrumentation) StartSpan(ctx context.Context, info RequestInfo) (context.Context, Span) {
+	return ctx, noopSpan{}
+}
+
+// RecordCall does nothing.
+func (NoopInstrumentation) RecordCall(info RequestInfo, result CallResult) {}
+
+// RecordedSpan is a span kept by an InMemoryInstrumentation.
+type RecordedSpan struct {
+	RequestInfo
+	CallResult
+	// Ended reports whether the call is over.
+	Ended bool
+}
+
+// InMemoryInstrumentation keeps every span and metric in memory. It is meant
+// for tests.
+type InMemoryInstrumentation struct {
+	mu        sync.Mutex
+	spans     []*RecordedSpan
+	calls     map[RequestInfo]int
+	errors    map[RequestInfo]int
+	latencies map[RequestInfo][]time.Duration
+}
+
+// NewInMemoryInstrumentation returns an empty InMemoryInstrumentation.
+func NewInMemoryInstrumentation() *InMemoryInstrumentation {
+	return &InMemoryInstrumentation{
+		calls:     make(map[RequestInfo]int),
+		errors:    make(map[RequestInfo]int),
+		latencies: make(map[RequestInfo][]time.Duration),
+	}
+}
+
+type inMemorySpan struct {
+	i    *InMemoryInstrumentation
+	span *RecordedSpan
+}
+
+func (s inMemorySpan) End(result CallResult) {
+	s.i.mu.Lock()
+	defer s.i.mu.Unlock()
+	s.span.CallResult = result
+	s.span.Ended = true
+}
+
+// StartSpan records a new span.
+func (i *InMemoryInstrumentation) StartSpan(ctx context.Context, info RequestInfo) (context.Context, Span) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	span := &RecordedSpan{RequestInfo: info}
+	i.spans = append(i.spans, span)
+	return ctx, inMemorySpan{i: i, span: span}
+}
+
+// RecordCall updates the call, error and latency metrics.
+func (i *InMemoryInstrumentation) RecordCall(info RequestInfo, result CallResult) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	i.calls[info]++
+	if result.Failed() {
+		i.errors[info]++
+	}
+	i.latencies[info] = append(i.latencies[info], result.Latency)
+}
+
+// Spans returns every span recorded so far, in the order they were started.
+func (i *InMemoryInstrumentation) Spans() []RecordedSpan {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	spans := make([]RecordedSpan, len(i.spans))
+	for n, s := range i.spans {
+		spans[n] = *s
+	}
+	return spans
+}
+
+// Calls returns the number of calls recorded for info.
+func (i *InMemoryInstrumentation) Calls(info RequestInfo) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.calls[info]
+}
+
+// Errors returns the number of failed calls recorded for info.
+func (i *InMemoryInstrumentation) Errors(info RequestInfo) int {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return i.errors[info]
+}
+
+// Latencies returns the latency of every call recorded for info.
+func (i *InMemoryInstrumentation) Latencies(info RequestInfo) []time.Duration {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+	return append([]time.Duration(nil), i.latencies[info]...)
+}
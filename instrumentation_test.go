@@ -0,0 +1,53 @@
+package pagerduty
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestInstrumentationRecordsCalls(t *testing.T) {
+	calls := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		if r.URL.Path == "/services/PMISSING" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"service": {"id": "PSVC1"}}`))
+	}))
+	defer server.Close()
+	rec := NewInMemoryInstrumentation()
+	client := NewClient("123",
+		WithCustomHost(server.URL),
+		WithRetryPolicy(fastRetryPolicy()),
+		WithInstrumentation(rec))
+
+	if _, err := client.GetService("PSVC1"); err != nil {
+		t.Fatal(err)
+	}
+	client.GetService("PMISSING")
+
+	spans := rec.Spans()
+	if len(spans) != 2 {
+		t.Fatalf("expected 2 spans, got %d", len(spans))
+	}
+	first := spans[0]
+	if !first.Ended || first.ResourceType != ServiceResourceType || first.Operation != "get" ||
+		first.StatusCode != http.StatusOK || first.Retries != 1 {
+		t.Errorf("unexpected span %+v", first)
+	}
+	if spans[1].StatusCode != http.StatusNotFound || spans[1].Retries != 0 {
+		t.Errorf("unexpected span %+v", spans[1])
+	}
+
+	info := RequestInfo{ResourceType: ServiceResourceType, Operation: "get"}
+	if rec.Calls(info) != 2 || rec.Errors(info) != 1 || len(rec.Latencies(info)) != 2 {
+		t.Errorf("unexpected metrics: %d calls, %d errors, %d latencies",
+			rec.Calls(info), rec.Errors(info), len(rec.Latencies(info)))
+	}
+}
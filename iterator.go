@@ -0,0 +1,356 @@
+package pagerduty
+
+import "context"
+
+// pageFunc fetches a single page of a list endpoint.
+type pageFunc func(ctx context.Context, opts ...ResourceRequestOptionFunc) (ResourceList, error)
+
+// Iterator walks every page of a list endpoint, fetching the next page only
+// once the current one has been consumed. Offset-based and cursor-based
+// pagination are both supported: as soon as a page carries a next_cursor, the
+// following pages are requested by cursor:
+//
+//	it := client.Iterate(pagerduty.ServiceResourceType)
+//	for it.Next() {
+//		fmt.Println(it.Resource().GetSummary())
+//	}
+//	if err := it.Err(); err != nil {
+//		// handle the error
+//	}
+//
+// Callers may stop at any point, either by no longer calling Next or by
+// calling Stop.
+type Iterator struct {
+	// PageSize is the number of items requested per page. When zero, the
+	// API default is used.
+	PageSize uint
+	// MaxItems caps the number of items returned by the iterator. When zero,
+	// every item is returned.
+	MaxItems int
+
+	ctx     context.Context
+	fetch   pageFunc
+	opts    []ResourceRequestOptionFunc
+	page    []Resource
+	current Resource
+	offset  uint
+	cursor  string
+	seen    int
+	fetched bool
+	more    bool
+	done    bool
+	err     error
+}
+
+func newIterator(ctx context.Context, fetch pageFunc, opts []ResourceRequestOptionFunc) *Iterator {
+	return &Iterator{ctx: ctx, fetch: fetch, opts: opts}
+}
+
+// Iterate returns an Iterator over every resource of the given type.
+func (c *Client) Iterate(typ APIResourceType, opts ...ResourceRequestOptionFunc) *Iterator {
+	return c.IterateWithContext(context.Background(), typ, opts...)
+}
+
+// IterateWithContext is like Iterate but takes a context, used for every page
+// request.
+func (c *Client) IterateWithContext(ctx context.Context, typ APIResourceType, opts ...ResourceRequestOptionFunc) *Iterator {
+	return newIterator(ctx, func(ctx context.Context, opts ...ResourceRequestOptionFunc) (ResourceList, error) {
+		return c.listPage(ctx, typ, opts...)
+	}, opts)
+}
+
+// listPage fetches and decodes a single page of resources of the given type.
+func (c *Client) listPage(ctx context.Context, typ APIResourceType, opts ...ResourceRequestOptionFunc) (ResourceList, error) {
+	resp, err := c.ListResourcesWithContext(ctx, typ, opts...)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	list, err := APIListResponses.Get(typ, resp)
+	if err != nil {
+		return nil, err
+	}
+	return list, deserialize(resp, list)
+}
+
+// Next advances the iterator to the next resource, fetching a new page when
+// needed. It returns false once every resource has been returned, MaxItems has
+// been reached, Stop has been called or an error occurred.
+func (it *Iterator) Next() bool {
+	if it.done || (it.MaxItems > 0 && it.seen >= it.MaxItems) {
+		it.Stop()
+		return false
+	}
+	for len(it.page) == 0 {
+		if it.fetched && !it.more {
+			it.Stop()
+			return false
+		}
+		if err := it.fetchPage(); err != nil {
+			it.err = err
+			it.Stop()
+			return false
+		}
+	}
+	it.current, it.page = it.page[0], it.page[1:]
+	it.seen++
+	return true
+}
+
+func (it *Iterator) fetchPage() error {
+	opts := make([]ResourceRequestOptionFunc, 0, len(it.opts)+2)
+	opts = append(opts, it.opts...)
+	if it.cursor != "" {
+		opts = append(opts, WithCursor(it.cursor))
+	} else if it.offset > 0 {
+		opts = append(opts, WithOffset(it.offset))
+	}
+	if it.PageSize > 0 {
+		opts = append(opts, WithLimit(it.PageSize))
+	}
+	list, err := it.fetch(it.ctx, opts...)
+	if err != nil {
+		return err
+	}
+	it.page = list.GetResources()
+	it.fetched = true
+	switch next := list.GetNextCursor(); {
+	case next != "":
+		it.cursor = next
+		it.more = len(it.page) > 0
+	case it.cursor != "":
+		it.more = false
+	default:
+		it.offset += uint(len(it.page))
+		it.more = list.HasMore() && len(it.page) > 0
+	}
+	return nil
+}
+
+// Resource returns the resource the iterator is positioned on.
+func (it *Iterator) Resource() Resource {
+	return it.current
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator) Err() error {
+	return it.err
+}
+
+// Stop ends the iteration early. Subsequent calls to Next return false.
+func (it *Iterator) Stop() {
+	it.done = true
+	it.page = nil
+}
+
+// All returns every remaining resource.
+func (it *Iterator) All() ([]Resource, error) {
+	var all []Resource
+	for it.Next() {
+		all = append(all, it.Resource())
+	}
+	return all, it.Err()
+}
+
+// IncidentIterator iterates over incidents.
+type IncidentIterator struct {
+	*Iterator
+}
+
+// Incidents returns an iterator over every incident matching opts.
+func (c *Client) Incidents(opts ...ResourceRequestOptionFunc) *IncidentIterator {
+	return c.IncidentsWithContext(context.Background(), opts...)
+}
+
+// IncidentsWithContext is like Incidents but takes a context.
+func (c *Client) IncidentsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) *IncidentIterator {
+	return &IncidentIterator{c.IterateWithContext(ctx, IncidentResourceType, opts...)}
+}
+
+// Incident returns the incident the iterator is positioned on.
+func (it *IncidentIterator) Incident() Incident {
+	return it.Resource().(Incident)
+}
+
+// All returns every remaining incident.
+func (it *IncidentIterator) All() ([]Incident, error) {
+	var all []Incident
+	for it.Next() {
+		all = append(all, it.Incident())
+	}
+	return all, it.Err()
+}
+
+// UserIterator iterates over users.
+type UserIterator struct {
+	*Iterator
+}
+
+// Users returns an iterator over every user matching opts.
+func (c *Client) Users(opts ...ResourceRequestOptionFunc) *UserIterator {
+	return c.UsersWithContext(context.Background(), opts...)
+}
+
+// UsersWithContext is like Users but takes a context.
+func (c *Client) UsersWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) *UserIterator {
+	return &UserIterator{c.IterateWithContext(ctx, UserResourceType, opts...)}
+}
+
+// User returns the user the iterator is positioned on.
+func (it *UserIterator) User() User {
+	return it.Resource().(User)
+}
+
+// All returns every remaining user.
+func (it *UserIterator) All() ([]User, error) {
+	var all []User
+	for it.Next() {
+		all = append(all, it.User())
+	}
+	return all, it.Err()
+}
+
+// ServiceIterator iterates over services.
+type ServiceIterator struct {
+	*Iterator
+}
+
+// Services returns an iterator over every service matching opts.
+func (c *Client) Services(opts ...ResourceRequestOptionFunc) *ServiceIterator {
+	return c.ServicesWithContext(context.Background(), opts...)
+}
+
+// ServicesWithContext is like Services but takes a context.
+func (c *Client) ServicesWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) *ServiceIterator {
+	return &ServiceIterator{c.IterateWithContext(ctx, ServiceResourceType, opts...)}
+}
+
+// Service returns the service the iterator is positioned on.
+func (it *ServiceIterator) Service() Service {
+	return it.Resource().(Service)
+}
+
+// All returns every remaining service.
+func (it *ServiceIterator) All() ([]Service, error) {
+	var all []Service
+	for it.Next() {
+		all = append(all, it.Service())
+	}
+	return all, it.Err()
+}
+
+// ScheduleIterator iterates over schedules.
+type ScheduleIterator struct {
+	*Iterator
+}
+
+// Schedules returns an iterator over every schedule matching opts.
+func (c *Client) Schedules(opts ...ResourceRequestOptionFunc) *ScheduleIterator {
+	return c.SchedulesWithContext(context.Background(), opts...)
+}
+
+// SchedulesWithContext is like Schedules but takes a context.
+func (c *Client) SchedulesWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) *ScheduleIterator {
+	return &ScheduleIterator{c.IterateWithContext(ctx, ScheduleResourceType, opts...)}
+}
+
+// Schedule returns the schedule the iterator is positioned on.
+func (it *ScheduleIterator) Schedule() Schedule {
+	return it.Resource().(Schedule)
+}
+
+// All returns every remaining schedule.
+func (it *ScheduleIterator) All() ([]Schedule, error) {
+	var all []Schedule
+	for it.Next() {
+		all = append(all, it.Schedule())
+	}
+	return all, it.Err()
+}
+
+// LogEntryIterator iterates over log entries.
+type LogEntryIterator struct {
+	*Iterator
+}
+
+// LogEntries returns an iterator over every log entry matching opts.
+func (c *Client) LogEntries(opts ...ResourceRequestOptionFunc) *LogEntryIterator {
+	return c.LogEntriesWithContext(context.Background(), opts...)
+}
+
+// LogEntriesWithContext is like LogEntries but takes a context.
+func (c *Client) LogEntriesWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) *LogEntryIterator {
+	return &LogEntryIterator{c.IterateWithContext(ctx, LogEntryResourceType, opts...)}
+}
+
+// LogEntry returns the log entry the iterator is positioned on.
+func (it *LogEntryIterator) LogEntry() LogEntry {
+	return it.Resource().(LogEntry)
+}
+
+// All returns every remaining log entry.
+func (it *LogEntryIterator) All() ([]LogEntry, error) {
+	var all []LogEntry
+	for it.Next() {
+		all = append(all, it.LogEntry())
+	}
+	return all, it.Err()
+}
+
+// NotificationIterator iterates over notifications.
+type NotificationIterator struct {
+	*Iterator
+}
+
+// Notifications returns an iterator over every notification matching opts.
+func (c *Client) Notifications(opts ...ResourceRequestOptionFunc) *NotificationIterator {
+	return c.NotificationsWithContext(context.Background(), opts...)
+}
+
+// NotificationsWithContext is like Notifications but takes a context.
+func (c *Client) NotificationsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) *NotificationIterator {
+	return &NotificationIterator{c.IterateWithContext(ctx, NotificationResourceType, opts...)}
+}
+
+// Notification returns the notification the iterator is positioned on.
+func (it *NotificationIterator) Notification() Notification {
+	return it.Resource().(Notification)
+}
+
+// All returns every remaining notification.
+func (it *NotificationIterator) All() ([]Notification, error) {
+	var all []Notification
+	for it.Next() {
+		all = append(all, it.Notification())
+	}
+	return all, it.Err()
+}
+
+// OnCallIterator iterates over on-call entries.
+type OnCallIterator struct {
+	*Iterator
+}
+
+// OnCalls returns an iterator over every on-call entry matching opts.
+func (c *Client) OnCalls(opts ...ResourceRequestOptionFunc) *OnCallIterator {
+	return c.OnCallsWithContext(context.Background(), opts...)
+}
+
+// OnCallsWithContext is like OnCalls but takes a context.
+func (c *Client) OnCallsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) *OnCallIterator {
+	return &OnCallIterator{c.IterateWithContext(ctx, OnCallResourceType, opts...)}
+}
+
+// OnCall returns the on-call entry the iterator is positioned on.
+func (it *OnCallIterator) OnCall() OnCall {
+	return it.Resource().(OnCall)
+}
+
+// All returns every remaining on-call entry.
+func (it *OnCallIterator) All() ([]OnCall, error) {
+	var all []OnCall
+	for it.Next() {
+		all = append(all, it.OnCall())
+	}
+	return all, it.Err()
+}
@@ -0,0 +1,137 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+// pagingServer serves total items of the given collection, limit at a time.
+func pagingServer(t *testing.T, path, key string, total int, requests *[]string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		*requests = append(*requests, r.URL.RawQuery)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		if limit == 0 {
+			limit = 25
+		}
+		var items []map[string]interface{}
+		for i := offset; i < total && i < offset+limit; i++ {
+			items = append(items, map[string]interface{}{"id": fmt.Sprintf("P%d", i), "summary": fmt.Sprintf("item %d", i)})
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			key:      items,
+			"limit":  limit,
+			"offset": offset,
+			"more":   offset+limit < total,
+		})
+	}))
+}
+
+func TestIteratorWalksAllPages(t *testing.T) {
+	var requests []string
+	server := pagingServer(t, "/incidents", "incidents", 5, &requests)
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	it := client.Incidents(WithStatuses("triggered"))
+	it.PageSize = 2
+	incidents, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incidents) != 5 || incidents[4].ID != "P4" {
+		t.Errorf("unexpected incidents %+v", incidents)
+	}
+	expected := []string{
+		"limit=2&statuses=triggered",
+		"limit=2&offset=2&statuses=triggered",
+		"limit=2&offset=4&statuses=triggered",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestIteratorMaxItems(t *testing.T) {
+	var requests []string
+	server := pagingServer(t, "/users", "users", 10, &requests)
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	it := client.Users()
+	it.PageSize = 3
+	it.MaxItems = 4
+	users, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 4 || len(requests) != 2 {
+		t.Errorf("expected 4 users in 2 requests, got %d in %d", len(users), len(requests))
+	}
+}
+
+func TestIteratorStop(t *testing.T) {
+	var requests []string
+	server := pagingServer(t, "/oncalls", "oncalls", 10, &requests)
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	it := client.OnCalls()
+	for it.Next() {
+		it.Stop()
+	}
+	if it.Next() || it.Err() != nil || len(requests) != 1 {
+		t.Errorf("iterator did not stop cleanly after %d requests: %v", len(requests), it.Err())
+	}
+}
+
+func TestIteratorError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		w.Write([]byte(`{"error": {"code": 2000, "message": "An internal error has occurred"}}`))
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	it := client.Services()
+	if it.Next() {
+		t.Error("expected no items")
+	}
+	if it.Err() == nil {
+		t.Error("expected an error")
+	}
+}
+
+func TestIteratorFollowsCursors(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.URL.RawQuery)
+		pages := map[string]string{
+			"":   `{"log_entries": [{"id": "L0"}, {"id": "L1"}], "next_cursor": "c1"}`,
+			"c1": `{"log_entries": [{"id": "L2"}, {"id": "L3"}], "next_cursor": "c2"}`,
+			"c2": `{"log_entries": [{"id": "L4"}]}`,
+		}
+		w.Write([]byte(pages[r.URL.Query().Get("cursor")]))
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	entries, err := client.LogEntries(WithSince("2019-01-01")).All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 5 || entries[4].ID != "L4" {
+		t.Errorf("unexpected log entries %+v", entries)
+	}
+	expected := []string{"since=2019-01-01", "cursor=c1&since=2019-01-01", "cursor=c2&since=2019-01-01"}
+	if fmt.Sprint(requests) != fmt.Sprint(expected) {
+		t.Errorf("expected requests %v, got %v", expected, requests)
+	}
+}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -54,6 +55,14 @@ type ListLogEntryResponse struct {
 	LogEntries []LogEntry `json:"log_entries"`
 }
 
+func (list ListLogEntryResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.LogEntries))
+	for i, r := range list.LogEntries {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListLogEntriesOptions is the data structure used when calling the ListLogEntry API endpoint.
 type ListLogEntriesOptions struct {
 	APIListObject
@@ -66,7 +75,12 @@ type ListLogEntriesOptions struct {
 
 // ListLogEntries lists all of the incident log entries across the entire account.
 func (c *Client) ListLogEntries(opts ...ResourceRequestOptionFunc) (*ListLogEntryResponse, error) {
-	resp, err := c.ListResources(LogEntryResourceType, opts...)
+	return c.ListLogEntriesWithContext(context.Background(), opts...)
+}
+
+// ListLogEntriesWithContext is like ListLogEntries but takes a context.
+func (c *Client) ListLogEntriesWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListLogEntryResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, LogEntryResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -75,7 +89,12 @@ func (c *Client) ListLogEntries(opts ...ResourceRequestOptionFunc) (*ListLogEntr
 }
 
 func (c *Client) GetLogEntry(id string) (*LogEntry, error) {
-	resp, err := c.GetResource(LogEntryResourceType, id)
+	return c.GetLogEntryWithContext(context.Background(), id)
+}
+
+// GetLogEntryWithContext is like GetLogEntry but takes a context.
+func (c *Client) GetLogEntryWithContext(ctx context.Context, id string) (*LogEntry, error) {
+	resp, err := c.GetResourceWithContext(ctx, LogEntryResourceType, id)
 	if err != nil {
 		return nil, err
 	}
@@ -0,0 +1,172 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"time"
+)
+
+// redacted replaces sensitive values in logged requests and responses.
+const redacted = "REDACTED"
+
+// redactedKeys are the JSON keys whose values are never logged: integration
+// and routing keys, contact method addresses and secrets.
+var redactedKeys = map[string]bool{
+	"integration_key": true,
+	"routing_key":     true,
+	"service_key":     true,
+	"address":         true,
+	"token":           true,
+	"secret":          true,
+}
+
+// RequestLog describes a call made by the client.
+type RequestLog struct {
+	Method       string
+	Path         string
+	Query        string
+	ResourceType APIResourceType
+	Operation    string
+	// StatusCode is the status of the response, or 0 when no response was
+	// received.
+	StatusCode int
+	// Latency is the time spent on the call, retries included.
+	Latency time.Duration
+	// RequestID is the X-Request-Id returned by PagerDuty.
+	RequestID string
+	// Attempts is the number of times the request was sent.
+	Attempts int
+	Err      error
+
+	// RequestHeader, RequestBody and ResponseBody are only set by loggers
+	// installed with WithDebugLogger. Credentials, integration keys and contact
+	// method addresses are redacted.
+	RequestHeader http.Header
+	RequestBody   string
+	ResponseBody  string
+}
+
+// Logger receives a RequestLog for every call made by the client.
+type Logger interface {
+	LogRequest(RequestLog)
+}
+
+// LoggerFunc adapts a function to the Logger interface.
+type LoggerFunc func(RequestLog)
+
+// LogRequest calls f(l).
+func (f LoggerFunc) LogRequest(l RequestLog) {
+	f(l)
+}
+
+// WithLogger makes the client report every call to l.
+func WithLogger(l Logger) NewClientOptionFunc {
+	return func(client *Client) {
+		client.logger = l
+		client.logBodies = false
+	}
+}
+
+// WithDebugLogger is like WithLogger, but also dumps the headers and bodies of
+// requests and responses, with sensitive values redacted.
+func WithDebugLogger(l Logger) NewClientOptionFunc {
+	return func(client *Client) {
+		client.logger = l
+		client.logBodies = true
+	}
+}
+
+// callStats collects what happens to a request while it is sent.
+type callStats struct {
+	attempts int
+}
+
+type callStatsKey struct{}
+
+func callStatsFromContext(ctx context.Context) *callStats {
+	stats, _ := ctx.Value(callStatsKey{}).(*callStats)
+	return stats
+}
+
+// logRequest reports a finished call to the client's logger.
+func (c *Client) logRequest(req *http.Request, resp *http.Response, err error, info RequestInfo, stats *callStats, latency time.Duration) {
+	l := RequestLog{
+		Method:       req.Method,
+		Path:         req.URL.Path,
+		Query:        req.URL.RawQuery,
+		ResourceType: info.ResourceType,
+		Operation:    info.Operation,
+		Latency:      latency,
+		Attempts:     stats.attempts,
+		Err:          err,
+	}
+	if resp != nil {
+		l.StatusCode = resp.StatusCode
+		l.RequestID = resp.Header.Get("X-Request-Id")
+	}
+	if c.logBodies {
+		l.RequestHeader = redactHeader(req.Header)
+		if req.GetBody != nil {
+			if body, err := req.GetBody(); err == nil {
+				data, _ := ioutil.ReadAll(body)
+				body.Close()
+				l.RequestBody = redactBody(data)
+			}
+		}
+		if resp != nil && resp.Body != nil {
+			data, _ := ioutil.ReadAll(resp.Body)
+			resp.Body.Close()
+			resp.Body = ioutil.NopCloser(bytes.NewReader(data))
+			l.ResponseBody = redactBody(data)
+		}
+	}
+	c.logger.LogRequest(l)
+}
+
+func redactHeader(h http.Header) http.Header {
+	h = h.Clone()
+	if h.Get("Authorization") != "" {
+		h.Set("Authorization", "Token token="+redacted)
+	}
+	return h
+}
+
+// redactBody replaces the values of redactedKeys in a JSON body. Bodies that
+// are not JSON are returned unchanged.
+func redactBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err != nil {
+		return string(data)
+	}
+	out, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return string(data)
+	}
+	return string(out)
+}
+
+func redactValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if redactedKeys[k] {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(val)
+			}
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = redactValue(val)
+		}
+	}
+	return v
+}
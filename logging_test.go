@@ -0,0 +1,67 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestLoggerReportsCalls(t *testing.T) {
+	server := errorServer(http.StatusNotFound, `{"error": {"code": 2100, "message": "Not Found"}}`)
+	defer server.Close()
+	var logs []RequestLog
+	client := NewClient("secret-token",
+		WithCustomHost(server.URL),
+		WithLogger(LoggerFunc(func(l RequestLog) { logs = append(logs, l) })))
+
+	client.GetService("PSVC1", WithOptions(&GetServiceOptions{Includes: []string{"teams"}}))
+	if len(logs) != 1 {
+		t.Fatalf("expected 1 log, got %d", len(logs))
+	}
+	l := logs[0]
+	if l.Method != http.MethodGet || l.Path != "/services/PSVC1" || l.Query != "include%5B%5D=teams" {
+		t.Errorf("unexpected request %s %s?%s", l.Method, l.Path, l.Query)
+	}
+	if l.StatusCode != http.StatusNotFound || l.RequestID != "req-123" || l.Attempts != 1 || l.Operation != "get" {
+		t.Errorf("unexpected log %+v", l)
+	}
+	if l.RequestHeader != nil || l.ResponseBody != "" {
+		t.Errorf("expected no dumps without debug logging")
+	}
+}
+
+func TestDebugLoggerRedacts(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"contact_method": {"id": "PC1", "type": "email_contact_method", "address": "jane@example.com"}}`))
+	}))
+	defer server.Close()
+	var logs []RequestLog
+	client := NewClient("secret-token",
+		WithCustomHost(server.URL),
+		WithDebugLogger(LoggerFunc(func(l RequestLog) { logs = append(logs, l) })))
+
+	resp, err := client.post(context.Background(), "/users/PU1/contact_methods", map[string]interface{}{
+		"contact_method": map[string]string{"type": "email_contact_method", "address": "jane@example.com"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var body struct {
+		ContactMethod struct{ Address string } `json:"contact_method"`
+	}
+	if err := deserialize(resp, &body); err != nil || body.ContactMethod.Address != "jane@example.com" {
+		t.Errorf("response body was not preserved: %v %+v", err, body)
+	}
+
+	l := logs[0]
+	if auth := l.RequestHeader.Get("Authorization"); auth != "Token token=REDACTED" {
+		t.Errorf("authorization header was not redacted: %q", auth)
+	}
+	for _, dump := range []string{l.RequestBody, l.ResponseBody} {
+		if strings.Contains(dump, "jane@example.com") || !strings.Contains(dump, `"address":"REDACTED"`) {
+			t.Errorf("address was not redacted in %s", dump)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -36,6 +37,14 @@ type ListMaintenanceWindowsResponse struct {
 	MaintenanceWindows []MaintenanceWindow `json:"maintenance_windows"`
 }
 
+func (list ListMaintenanceWindowsResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.MaintenanceWindows))
+	for i, r := range list.MaintenanceWindows {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListMaintenanceWindowsOptions is the data structure used when calling the ListMaintenanceWindows API endpoint.
 type ListMaintenanceWindowsOptions struct {
 	APIListObject
@@ -48,7 +57,12 @@ type ListMaintenanceWindowsOptions struct {
 
 // ListMaintenanceWindows lists existing maintenance windows, optionally filtered by service and/or team, or whether they are from the past, present or future.
 func (c *Client) ListMaintenanceWindows(opts ...ResourceRequestOptionFunc) (*ListMaintenanceWindowsResponse, error) {
-	resp, err := c.ListResources(MaintenanceWindowResourceType, opts...)
+	return c.ListMaintenanceWindowsWithContext(context.Background(), opts...)
+}
+
+// ListMaintenanceWindowsWithContext is like ListMaintenanceWindows but takes a context.
+func (c *Client) ListMaintenanceWindowsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListMaintenanceWindowsResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, MaintenanceWindowResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -58,7 +72,12 @@ func (c *Client) ListMaintenanceWindows(opts ...ResourceRequestOptionFunc) (*Lis
 
 // CreateMaintenanceWindows creates a new maintenance window for the specified services.
 func (c *Client) CreateMaintenanceWindows(m MaintenanceWindow) (*MaintenanceWindow, error) {
-	resp, err := c.CreateResource(m)
+	return c.CreateMaintenanceWindowsWithContext(context.Background(), m)
+}
+
+// CreateMaintenanceWindowsWithContext is like CreateMaintenanceWindows but takes a context.
+func (c *Client) CreateMaintenanceWindowsWithContext(ctx context.Context, m MaintenanceWindow) (*MaintenanceWindow, error) {
+	resp, err := c.CreateResourceWithContext(ctx, m)
 	if err != nil {
 		return nil, err
 	}
@@ -68,7 +87,12 @@ func (c *Client) CreateMaintenanceWindows(m MaintenanceWindow) (*MaintenanceWind
 
 // DeleteMaintenanceWindow deletes an existing maintenance window if it's in the future, or ends it if it's currently on-going.
 func (c *Client) DeleteMaintenanceWindow(id string) error {
-	return c.DeleteResource(MaintenanceWindowResourceType, id)
+	return c.DeleteMaintenanceWindowWithContext(context.Background(), id)
+}
+
+// DeleteMaintenanceWindowWithContext is like DeleteMaintenanceWindow but takes a context.
+func (c *Client) DeleteMaintenanceWindowWithContext(ctx context.Context, id string) error {
+	return c.DeleteResourceWithContext(ctx, MaintenanceWindowResourceType, id)
 }
 
 // GetMaintenanceWindowOptions is the data structure used when calling the GetMaintenanceWindow API endpoint.
@@ -78,7 +102,12 @@ type GetMaintenanceWindowOptions struct {
 
 // GetMaintenanceWindow gets an existing maintenance window.
 func (c *Client) GetMaintenanceWindow(id string, opts ...ResourceRequestOptionFunc) (*MaintenanceWindow, error) {
-	res, err := c.GetResource(MaintenanceWindowResourceType, id, opts...)
+	return c.GetMaintenanceWindowWithContext(context.Background(), id, opts...)
+}
+
+// GetMaintenanceWindowWithContext is like GetMaintenanceWindow but takes a context.
+func (c *Client) GetMaintenanceWindowWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*MaintenanceWindow, error) {
+	res, err := c.GetResourceWithContext(ctx, MaintenanceWindowResourceType, id, opts...)
 	if err != nil {
 	    return nil, err
 	}
@@ -88,7 +117,12 @@ func (c *Client) GetMaintenanceWindow(id string, opts ...ResourceRequestOptionFu
 
 // UpdateMaintenanceWindow updates an existing maintenance window.
 func (c *Client) UpdateMaintenanceWindow(m MaintenanceWindow) (*MaintenanceWindow, error) {
-	resp, err := c.UpdateResource(m)
+	return c.UpdateMaintenanceWindowWithContext(context.Background(), m)
+}
+
+// UpdateMaintenanceWindowWithContext is like UpdateMaintenanceWindow but takes a context.
+func (c *Client) UpdateMaintenanceWindowWithContext(ctx context.Context, m MaintenanceWindow) (*MaintenanceWindow, error) {
+	resp, err := c.UpdateResourceWithContext(ctx, m)
 	if err != nil {
 		return nil, err
 	}
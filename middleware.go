@@ -0,0 +1,143 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// Doer sends an HTTP request and returns its response. Any HTTPClient is a
+// Doer.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// DoerFunc adapts a function to the Doer interface.
+type DoerFunc func(*http.Request) (*http.Response, error)
+
+// Do calls f(req).
+func (f DoerFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// Middleware wraps the Doer performing a call to the PagerDuty API. It can
+// mutate the request, observe the response, or answer without calling next at
+// all, for instance to serve a cached response. The RequestInfo of the call is
+// available through RequestInfoFromContext(req.Context()).
+type Middleware func(next Doer) Doer
+
+// WithMiddleware adds middleware to the client. The first middleware given is
+// the outermost one, and sees each call before the others. Middleware wraps a
+// whole call, so retries and rate limiting happen inside next.
+func WithMiddleware(m ...Middleware) NewClientOptionFunc {
+	return func(client *Client) {
+		client.middleware = append(client.middleware, m...)
+	}
+}
+
+// RequestInfo describes the API call a request is made for.
+type RequestInfo struct {
+	// ResourceType is the type of the resource the call acts on, such as
+	// IncidentResourceType for /incidents/{id}/notes.
+	ResourceType APIResourceType
+	// Operation is what the call does on the resource: "list", "get",
+	// "create", "update" or "delete". Calls on a sub-resource append its name,
+	// as in "list_notes" or "create_snooze".
+	Operation string
+}
+
+type requestInfoKey struct{}
+
+// RequestInfoFromContext returns the RequestInfo stored in the context of a
+// request sent by the client.
+func RequestInfoFromContext(ctx context.Context) (RequestInfo, bool) {
+	info, ok := ctx.Value(requestInfoKey{}).(RequestInfo)
+	return info, ok
+}
+
+// resolveRequestInfo derives the RequestInfo of a REST API call from its
+// method and path.
+func resolveRequestInfo(method, path string) RequestInfo {
+	if i := strings.IndexByte(path, '?'); i >= 0 {
+		path = path[:i]
+	}
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+	info := RequestInfo{ResourceType: resourceTypeForCollection(segments[0])}
+	collection := len(segments)%2 == 1
+	switch method {
+	case http.MethodGet:
+		if collection {
+			info.Operation = "list"
+		} else {
+			info.Operation = "get"
+		}
+	case http.MethodPost:
+		info.Operation = "create"
+	case http.MethodPut:
+		info.Operation = "update"
+	case http.MethodDelete:
+		info.Operation = "delete"
+	default:
+		info.Operation = strings.ToLower(method)
+	}
+	if len(segments) > 2 {
+		sub := segments[len(segments)-1]
+		if !collection {
+			sub = segments[len(segments)-2]
+		}
+		info.Operation += "_" + sub
+	}
+	return info
+}
+
+// resourceTypeForCollection returns the resource type whose plural is name.
+func resourceTypeForCollection(name string) APIResourceType {
+	for typ := range APIResponses {
+		if typ.Plural().String() == name {
+			return typ
+		}
+	}
+	for typ := range APIListResponses {
+		if typ.Plural().String() == name {
+			return typ
+		}
+	}
+	return APIResourceType(name)
+}
+
+// execute runs req through the client's middleware, then sends it. The call
+// is traced, measured and logged.
+func (c *Client) execute(req *http.Request, info RequestInfo, limiter RateLimiter) (*http.Response, error) {
+	instrumentation := c.instrumentation
+	if instrumentation == nil {
+		instrumentation = NoopInstrumentation{}
+	}
+	ctx, span := instrumentation.StartSpan(req.Context(), info)
+	stats := &callStats{}
+	ctx = context.WithValue(ctx, requestInfoKey{}, info)
+	req = req.WithContext(context.WithValue(ctx, callStatsKey{}, stats))
+	var d Doer = DoerFunc(func(req *http.Request) (*http.Response, error) {
+		return c.send(req, limiter)
+	})
+	for i := len(c.middleware) - 1; i >= 0; i-- {
+		d = c.middleware[i](d)
+	}
+	start := time.Now()
+	resp, err := d.Do(req)
+	latency := time.Since(start)
+
+	result := CallResult{Latency: latency, Err: err}
+	if resp != nil {
+		result.StatusCode = resp.StatusCode
+	}
+	if stats.attempts > 1 {
+		result.Retries = stats.attempts - 1
+	}
+	span.End(result)
+	instrumentation.RecordCall(info, result)
+	if c.logger != nil {
+		c.logRequest(req, resp, err, info, stats, latency)
+	}
+	return resp, err
+}
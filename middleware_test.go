@@ -0,0 +1,73 @@
+package pagerduty
+
+import (
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestResolveRequestInfo(t *testing.T) {
+	cases := []struct {
+		method, path string
+		expected     RequestInfo
+	}{
+		{http.MethodGet, "/incidents", RequestInfo{IncidentResourceType, "list"}},
+		{http.MethodGet, "/escalation_policies/P1", RequestInfo{EscalationPolicyResourceType, "get"}},
+		{http.MethodPost, "/incidents/P1/notes", RequestInfo{IncidentResourceType, "create_notes"}},
+		{http.MethodDelete, "/escalation_policies/P1/escalation_rules/R1", RequestInfo{EscalationPolicyResourceType, "delete_escalation_rules"}},
+		{http.MethodGet, "/oncalls?since=2019-01-01", RequestInfo{OnCallResourceType, "list"}},
+	}
+	for _, c := range cases {
+		if info := resolveRequestInfo(c.method, c.path); info != c.expected {
+			t.Errorf("%s %s: expected %+v, got %+v", c.method, c.path, c.expected, info)
+		}
+	}
+}
+
+func TestMiddlewareChain(t *testing.T) {
+	var calls []string
+	tag := func(name string) Middleware {
+		return func(next Doer) Doer {
+			return DoerFunc(func(req *http.Request) (*http.Response, error) {
+				info, _ := RequestInfoFromContext(req.Context())
+				calls = append(calls, name+":"+info.ResourceType.String()+":"+info.Operation)
+				req.Header.Set("X-"+name, "1")
+				return next.Do(req)
+			})
+		}
+	}
+	var headers http.Header
+	transport := DoerFunc(func(req *http.Request) (*http.Response, error) {
+		headers = req.Header
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{"user": {"id": "PU1"}}`))}, nil
+	})
+	client := NewClient("123", WithCustomClient(transport), WithMiddleware(tag("Outer"), tag("Inner")))
+
+	if _, err := client.GetUser("PU1"); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Join(calls, ",") != "Outer:user:get,Inner:user:get" {
+		t.Errorf("unexpected middleware calls %v", calls)
+	}
+	if headers.Get("X-Outer") != "1" || headers.Get("X-Inner") != "1" {
+		t.Errorf("middleware headers did not reach the transport: %v", headers)
+	}
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	cache := func(next Doer) Doer {
+		return DoerFunc(func(req *http.Request) (*http.Response, error) {
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(`{"team": {"id": "PT1", "name": "cached"}}`))}, nil
+		})
+	}
+	client := NewClient("123", WithCustomClient(blockingHTTPClient{}), WithMiddleware(cache))
+
+	team, err := client.GetTeam("PT1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.Name != "cached" {
+		t.Errorf("expected the cached team, got %+v", team)
+	}
+}
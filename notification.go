@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -63,9 +64,22 @@ type ListNotificationsResponse struct {
 	Notifications []Notification
 }
 
+func (list ListNotificationsResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Notifications))
+	for i, r := range list.Notifications {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListNotifications lists notifications for a given time range, optionally filtered by type (sms_notification, email_notification, phone_notification, or push_notification).
 func (c *Client) ListNotifications(opts ...ResourceRequestOptionFunc) (*ListNotificationsResponse, error) {
-	resp, err := c.ListResources(NotificationResourceType, opts...)
+	return c.ListNotificationsWithContext(context.Background(), opts...)
+}
+
+// ListNotificationsWithContext is like ListNotifications but takes a context.
+func (c *Client) ListNotificationsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListNotificationsResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, NotificationResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
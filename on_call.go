@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"github.com/google/go-querystring/query"
 )
 
@@ -14,12 +15,40 @@ type OnCall struct {
 	End              string    `json:"end,omitempty"`
 }
 
+func (o OnCall) GetID() string {
+	return ""
+}
+
+func (o OnCall) GetType() APIResourceType {
+	return OnCallResourceType
+}
+
+func (o OnCall) GetSummary() string {
+	return o.User.Summary
+}
+
+func (o OnCall) GetSelf() string {
+	return ""
+}
+
+func (o OnCall) GetHTMLURL() string {
+	return ""
+}
+
 // ListOnCallsResponse is the data structure returned from calling the ListOnCalls API endpoint.
 type ListOnCallsResponse struct {
 	APIListObject
 	OnCalls []OnCall `json:"oncalls"`
 }
 
+func (list ListOnCallsResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.OnCalls))
+	for i, r := range list.OnCalls {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListOnCallOptions is the data structure used when calling the ListOnCalls API endpoint.
 type ListOnCallOptions struct {
 	APIListObject
@@ -35,11 +64,16 @@ type ListOnCallOptions struct {
 
 // ListOnCalls list the on-call entries during a given time range.
 func (c *Client) ListOnCalls(o ListOnCallOptions) (*ListOnCallsResponse, error) {
+	return c.ListOnCallsWithContext(context.Background(), o)
+}
+
+// ListOnCallsWithContext is like ListOnCalls but takes a context.
+func (c *Client) ListOnCallsWithContext(ctx context.Context, o ListOnCallOptions) (*ListOnCallsResponse, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get("/oncalls?" + v.Encode())
+	resp, err := c.get(ctx, "/oncalls?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
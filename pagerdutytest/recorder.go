@@ -0,0 +1,298 @@
+package pagerdutytest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Mode selects whether a Recorder records or replays traffic.
+type Mode int
+
+const (
+	// ModeReplay answers requests from a cassette, without network access.
+	ModeReplay Mode = iota
+	// ModeRecord sends requests through the wrapped client and saves them to
+	// the cassette on Close.
+	ModeRecord
+)
+
+// redacted replaces scrubbed values in cassettes.
+const redacted = "REDACTED"
+
+// scrubbedKeys are the JSON keys whose values are never written to a
+// cassette.
+var scrubbedKeys = map[string]bool{
+	"email":           true,
+	"address":         true,
+	"phone_number":    true,
+	"integration_key": true,
+	"routing_key":     true,
+	"service_key":     true,
+	"token":           true,
+	"secret":          true,
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// Doer is the interface of the HTTP client wrapped by a Recorder. It matches
+// the HTTPClient interface of the pagerduty package.
+type Doer interface {
+	Do(*http.Request) (*http.Response, error)
+}
+
+// Cassette is the content of a cassette file.
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Interaction is a recorded request and its response.
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is the scrubbed part of a request kept in a cassette.
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Host   string `json:"host"`
+	Path   string `json:"path"`
+	// Query is the normalized query string: parameters and their values are
+	// sorted, so requests match whatever order they were encoded in.
+	Query string `json:"query,omitempty"`
+	Body  string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a response kept in a cassette.
+type RecordedResponse struct {
+	StatusCode int         `json:"status_code"`
+	Header     http.Header `json:"header,omitempty"`
+	Body       string      `json:"body,omitempty"`
+}
+
+func (r RecordedRequest) String() string {
+	s := r.Method + " " + r.Path
+	if r.Query != "" {
+		s += "?" + r.Query
+	}
+	return s
+}
+
+// Recorder is an HTTP client recording traffic to a cassette file, or
+// replaying it from one. It can be passed to WithCustomClient:
+//
+//	rec, err := pagerdutytest.NewRecorder("testdata/services.json", pagerdutytest.ModeReplay, nil)
+//	if err != nil {
+//		t.Fatal(err)
+//	}
+//	defer rec.Close()
+//	client := pagerduty.NewClient(token, pagerduty.WithCustomClient(rec))
+//
+// Before being saved, interactions are scrubbed: the Authorization header is
+// dropped, email addresses are replaced, and so are the values of keys such as
+// integration_key or address in JSON bodies.
+//
+// When replaying, requests are matched on their method, path and normalized
+// query, and each interaction is used once, in the order it was recorded. A
+// request without a matching interaction fails with an error.
+type Recorder struct {
+	// Scrub, when set, is called on every interaction before it is saved, in
+	// addition to the default scrubbing.
+	Scrub func(*Interaction)
+
+	mode     Mode
+	path     string
+	next     Doer
+	mu       sync.Mutex
+	cassette Cassette
+	used     []bool
+}
+
+// NewRecorder returns a Recorder for the cassette file at path. In
+// ModeRecord, requests are sent with next, or http.DefaultClient when next is
+// nil. In ModeReplay, the cassette is loaded from path.
+func NewRecorder(path string, mode Mode, next Doer) (*Recorder, error) {
+	r := &Recorder{mode: mode, path: path, next: next}
+	if r.next == nil {
+		r.next = http.DefaultClient
+	}
+	if mode == ModeReplay {
+		data, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("could not decode cassette %s: %v", path, err)
+		}
+		r.used = make([]bool, len(r.cassette.Interactions))
+	}
+	return r, nil
+}
+
+// Do records or replays req.
+func (r *Recorder) Do(req *http.Request) (*http.Response, error) {
+	if r.mode == ModeRecord {
+		return r.record(req)
+	}
+	return r.replay(req)
+}
+
+// Close saves the cassette when recording.
+func (r *Recorder) Close() error {
+	if r.mode != ModeRecord {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(r.path, append(data, '\n'), 0644)
+}
+
+// Unused returns the recorded requests that have not been replayed.
+func (r *Recorder) Unused() []RecordedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var unused []RecordedRequest
+	for i, used := range r.used {
+		if !used {
+			unused = append(unused, r.cassette.Interactions[i].Request)
+		}
+	}
+	return unused
+}
+
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	var reqBody []byte
+	if req.Body != nil {
+		data, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		reqBody = data
+		req.Body = ioutil.NopCloser(bytes.NewReader(data))
+	}
+	resp, err := r.next.Do(req)
+	if err != nil {
+		return resp, err
+	}
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	header := resp.Header.Clone()
+	header.Del("Set-Cookie")
+	in := Interaction{
+		Request: RecordedRequest{
+			Method: req.Method,
+			Host:   req.URL.Host,
+			Path:   req.URL.Path,
+			Query:  normalizeQuery(req.URL.Query()),
+			Body:   scrubBody(reqBody),
+		},
+		Response: RecordedResponse{
+			StatusCode: resp.StatusCode,
+			Header:     header,
+			Body:       scrubBody(respBody),
+		},
+	}
+	if r.Scrub != nil {
+		r.Scrub(&in)
+	}
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, in)
+	r.mu.Unlock()
+	return resp, nil
+}
+
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	if req.Body != nil {
+		req.Body.Close()
+	}
+	want := RecordedRequest{
+		Method: req.Method,
+		Path:   req.URL.Path,
+		Query:  normalizeQuery(req.URL.Query()),
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for i, in := range r.cassette.Interactions {
+		got := in.Request
+		if r.used[i] || got.Method != want.Method || got.Path != want.Path || got.Query != want.Query {
+			continue
+		}
+		r.used[i] = true
+		return &http.Response{
+			Status:     fmt.Sprintf("%d %s", in.Response.StatusCode, http.StatusText(in.Response.StatusCode)),
+			StatusCode: in.Response.StatusCode,
+			Proto:      "HTTP/1.1",
+			ProtoMajor: 1,
+			ProtoMinor: 1,
+			Header:     in.Response.Header.Clone(),
+			Body:       ioutil.NopCloser(strings.NewReader(in.Response.Body)),
+			Request:    req,
+		}, nil
+	}
+	return nil, fmt.Errorf("pagerdutytest: no recorded interaction in %s for %s", r.path, want)
+}
+
+// normalizeQuery encodes q with its parameters and their values sorted, and
+// email addresses scrubbed.
+func normalizeQuery(q url.Values) string {
+	for k, values := range q {
+		for i, v := range values {
+			values[i] = emailPattern.ReplaceAllString(v, redacted)
+		}
+		sort.Strings(values)
+		q[k] = values
+	}
+	return q.Encode()
+}
+
+// scrubBody removes secrets and personal data from a request or response body.
+func scrubBody(data []byte) string {
+	if len(data) == 0 {
+		return ""
+	}
+	body := string(data)
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var v interface{}
+	if err := dec.Decode(&v); err == nil {
+		if out, err := json.Marshal(scrubValue(v)); err == nil {
+			body = string(out)
+		}
+	}
+	return emailPattern.ReplaceAllString(body, redacted)
+}
+
+func scrubValue(v interface{}) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, val := range v {
+			if scrubbedKeys[k] {
+				v[k] = redacted
+			} else {
+				v[k] = scrubValue(val)
+			}
+		}
+	case []interface{}:
+		for i, val := range v {
+			v[i] = scrubValue(val)
+		}
+	}
+	return v
+}
@@ -0,0 +1,83 @@
+package pagerdutytest_test
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	pagerduty "github.com/kylie-a/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty/pagerdutytest"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "users.json")
+
+	server := pagerdutytest.NewServer()
+	server.Add("users", map[string]interface{}{"name": "Jane", "email": "jane@example.com"})
+	rec, err := pagerdutytest.NewRecorder(path, pagerdutytest.ModeRecord, server.HTTPClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := pagerduty.NewClient("secret-token", pagerduty.WithCustomClient(rec))
+	params := map[string]string{"query": "jane", "time_zone": "UTC", "sort_by": "name"}
+	if _, err := client.ListUsers(pagerduty.WithParams(params)); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.Close(); err != nil {
+		t.Fatal(err)
+	}
+	server.Close()
+
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"secret-token", "jane@example.com"} {
+		if strings.Contains(string(data), secret) {
+			t.Errorf("cassette contains %q", secret)
+		}
+	}
+
+	rec, err = pagerdutytest.NewRecorder(path, pagerdutytest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client = pagerduty.NewClient("other-token", pagerduty.WithCustomClient(rec))
+	resp, err := client.ListUsers(pagerduty.WithParams(params))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(resp.Users) != 1 || resp.Users[0].Name != "Jane" {
+		t.Errorf("unexpected users %+v", resp.Users)
+	}
+	if unused := rec.Unused(); len(unused) != 0 {
+		t.Errorf("expected every interaction to be replayed, left %v", unused)
+	}
+}
+
+func TestReplayFailsOnUnmatchedRequest(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cassettes")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "empty.json")
+	if err := ioutil.WriteFile(path, []byte(`{"interactions": []}`), 0644); err != nil {
+		t.Fatal(err)
+	}
+	rec, err := pagerdutytest.NewRecorder(path, pagerdutytest.ModeReplay, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := pagerduty.NewClient("token", pagerduty.WithCustomClient(rec))
+	if _, err := client.ListUsers(); err == nil || !strings.Contains(err.Error(), "no recorded interaction") {
+		t.Errorf("expected an unmatched request error, got %v", err)
+	}
+}
@@ -0,0 +1,92 @@
+package pagerdutytest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+)
+
+// Request is a request received by the fake.
+type Request struct {
+	Method string
+	Path   string
+	Query  url.Values
+	Header http.Header
+	Body   []byte
+}
+
+func newRequest(r *http.Request, body []byte) Request {
+	return Request{
+		Method: r.Method,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Header: r.Header.Clone(),
+		Body:   body,
+	}
+}
+
+// DecodeBody decodes the JSON body of the request into v.
+func (r Request) DecodeBody(v interface{}) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// TB is the part of testing.TB used by the assertions of Server.
+type TB interface {
+	Helper()
+	Errorf(format string, args ...interface{})
+}
+
+// Requests returns every request received so far, in order.
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request(nil), s.requests...)
+}
+
+// ResetRequests forgets the requests received so far.
+func (s *Server) ResetRequests() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = nil
+}
+
+// Find returns the requests received with the given method and path.
+func (s *Server) Find(method, path string) []Request {
+	var found []Request
+	for _, r := range s.Requests() {
+		if r.Method == method && r.Path == path {
+			found = append(found, r)
+		}
+	}
+	return found
+}
+
+// AssertRequested fails the test unless a request was received with the
+// given method and path. It returns the last such request.
+func (s *Server) AssertRequested(t TB, method, path string) Request {
+	t.Helper()
+	found := s.Find(method, path)
+	if len(found) == 0 {
+		t.Errorf("expected a %s %s request, got none", method, path)
+		return Request{}
+	}
+	return found[len(found)-1]
+}
+
+// AssertNotRequested fails the test if a request was received with the given
+// method and path.
+func (s *Server) AssertNotRequested(t TB, method, path string) {
+	t.Helper()
+	if n := len(s.Find(method, path)); n > 0 {
+		t.Errorf("expected no %s %s request, got %d", method, path, n)
+	}
+}
+
+// AssertRequestCount fails the test unless exactly n requests were received
+// in total.
+func (s *Server) AssertRequestCount(t TB, n int) {
+	t.Helper()
+	if got := len(s.Requests()); got != n {
+		t.Errorf("expected %d requests, got %d", n, got)
+	}
+}
@@ -0,0 +1,526 @@
+// Package pagerdutytest provides an in-memory fake of the PagerDuty REST and
+// events APIs, for tests that need real HTTP round-trips without network
+// access.
+//
+//	server := pagerdutytest.NewServer()
+//	defer server.Close()
+//	id := server.Add("services", map[string]interface{}{"name": "Checkout"})
+//	client := pagerduty.NewClient("token", pagerduty.WithCustomClient(server.HTTPClient()))
+//	service, err := client.GetService(id)
+package pagerdutytest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// DefaultLimit is the page size used when a list request sets no limit.
+const DefaultLimit = 25
+
+// collections maps the collections served by the fake to the key of a single
+// item in request and response bodies.
+var collections = map[string]string{
+	"services":            "service",
+	"incidents":           "incident",
+	"schedules":           "schedule",
+	"users":               "user",
+	"teams":               "team",
+	"escalation_policies": "escalation_policy",
+	"maintenance_windows": "maintenance_window",
+	"oncalls":             "oncall",
+}
+
+// eventPaths are the events API endpoints accepted by the fake.
+var eventPaths = map[string]bool{
+	"/generic/2010-04-15/create_event.json": true,
+	"/v2/enqueue":                           true,
+	"/v2/change/enqueue":                    true,
+}
+
+// Object is a resource stored by the fake, as decoded from JSON.
+type Object map[string]interface{}
+
+// ID returns the id of the object.
+func (o Object) ID() string {
+	id, _ := o["id"].(string)
+	return id
+}
+
+// Fault makes the fake answer matching requests with an error.
+type Fault struct {
+	// Method and Path select the requests the fault applies to. Empty values
+	// match every method or path.
+	Method string
+	Path   string
+	// StatusCode is the status of the response.
+	StatusCode int
+	// Code and Message fill the PagerDuty error object of the response body.
+	Code    int
+	Message string
+	// Header is added to the response, for instance to set Retry-After.
+	Header http.Header
+	// Times is the number of requests the fault applies to. When zero, it
+	// applies until ClearFaults is called.
+	Times int
+}
+
+func (f *Fault) matches(r *http.Request) bool {
+	return (f.Method == "" || f.Method == r.Method) && (f.Path == "" || f.Path == r.URL.Path)
+}
+
+// Server is a fake PagerDuty API. It keeps resources in memory and serves
+// them through the same endpoints as the real API: listing with offset
+// pagination, and fetching, creating, updating and deleting single items.
+// Memberships, such as the users and escalation policies of a team, are added
+// with PUT and removed with DELETE on /{collection}/{id}/{list}/{member id},
+// and stored as references in the list of the parent object. The members of a
+// team are served from its users. Events sent to the events API are stored as
+// well.
+type Server struct {
+	// URL is the base URL of the fake, to be used with WithCustomHost.
+	URL string
+	// Token, when set, is the only API token accepted by the REST endpoints.
+	Token string
+
+	server   *httptest.Server
+	mu       sync.Mutex
+	items    map[string][]Object
+	events   []Object
+	faults   []*Fault
+	requests []Request
+	nextID   int
+}
+
+// NewServer starts a fake PagerDuty API. Callers must call Close when done.
+func NewServer() *Server {
+	s := &Server{items: make(map[string][]Object)}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts down the fake.
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// HTTPClient returns an HTTP client sending every request to the fake,
+// whatever its host. Passed to WithCustomClient, it makes both the REST and
+// the events API calls of a client reach the fake.
+func (s *Server) HTTPClient() *http.Client {
+	target, _ := url.Parse(s.URL)
+	return &http.Client{Transport: rewriteTransport{target: target, next: s.server.Client().Transport}}
+}
+
+type rewriteTransport struct {
+	target *url.URL
+	next   http.RoundTripper
+}
+
+func (t rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+	return t.next.RoundTrip(req)
+}
+
+// Add stores obj in collection, such as "services" or "escalation_policies",
+// and returns its id. An id is generated when obj has none.
+func (s *Server) Add(collection string, obj map[string]interface{}) string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.add(collection, Object(obj)).ID()
+}
+
+// Get returns the object of collection with the given id.
+func (s *Server) Get(collection, id string) (Object, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_, obj := s.find(collection, id)
+	return obj, obj != nil
+}
+
+// List returns every object of collection, in the order they were added.
+func (s *Server) List(collection string) []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Object(nil), s.items[collection]...)
+}
+
+// Events returns the payload of every event received, in order.
+func (s *Server) Events() []Object {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Object(nil), s.events...)
+}
+
+// AddFault makes the fake answer the requests matching f with an error.
+// Faults are checked in the order they were added.
+func (s *Server) AddFault(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+func (s *Server) add(collection string, obj Object) Object {
+	if obj == nil {
+		obj = Object{}
+	}
+	if obj.ID() == "" {
+		s.nextID++
+		obj["id"] = fmt.Sprintf("P%06d", s.nextID)
+	}
+	if _, ok := obj["type"]; !ok {
+		if typ, ok := collections[collection]; ok {
+			obj["type"] = typ
+		}
+	}
+	obj["self"] = s.URL + "/" + collection + "/" + obj.ID()
+	s.items[collection] = append(s.items[collection], obj)
+	return obj
+}
+
+func (s *Server) find(collection, id string) (int, Object) {
+	for i, obj := range s.items[collection] {
+		if obj.ID() == id {
+			return i, obj
+		}
+	}
+	return -1, nil
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.requests = append(s.requests, newRequest(r, body))
+
+	if f := s.fault(r); f != nil {
+		for k, v := range f.Header {
+			w.Header()[k] = v
+		}
+		writeError(w, f.StatusCode, f.Code, f.Message)
+		return
+	}
+	if eventPaths[r.URL.Path] {
+		s.serveEvent(w, r, body)
+		return
+	}
+	if s.Token != "" && r.Header.Get("Authorization") != "Token token="+s.Token {
+		writeError(w, http.StatusUnauthorized, 2006, "Authentication failed")
+		return
+	}
+
+	segments := strings.Split(strings.Trim(r.URL.Path, "/"), "/")
+	collection := segments[0]
+	key, ok := collections[collection]
+	if !ok || len(segments) > 4 {
+		writeError(w, http.StatusNotFound, 2100, "Not Found")
+		return
+	}
+	switch {
+	case len(segments) == 1 && r.Method == http.MethodGet:
+		s.serveList(w, r, collection)
+	case len(segments) == 1 && r.Method == http.MethodPost:
+		var payload map[string]Object
+		if err := json.Unmarshal(body, &payload); err != nil || payload[key] == nil {
+			writeError(w, http.StatusBadRequest, 2001, "Invalid Input Provided")
+			return
+		}
+		writeJSON(w, http.StatusCreated, map[string]interface{}{key: s.add(collection, payload[key])})
+	case len(segments) == 1 && r.Method == http.MethodPut:
+		s.serveBulkUpdate(w, body, collection)
+	case len(segments) == 2:
+		s.serveItem(w, r, body, collection, segments[1])
+	case len(segments) == 3:
+		s.serveSubresource(w, r, body, collection, segments[1], segments[2], "")
+	default:
+		s.serveSubresource(w, r, body, collection, segments[1], segments[2], segments[3])
+	}
+}
+
+// fault returns the fault matching r, if any, and consumes one of its uses.
+func (s *Server) fault(r *http.Request) *Fault {
+	for i, f := range s.faults {
+		if !f.matches(r) {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, collection string) {
+	q := r.URL.Query()
+	items := s.items[collection]
+	if query := strings.ToLower(q.Get("query")); query != "" {
+		var matched []Object
+		for _, obj := range items {
+			for _, field := range []string{"name", "summary", "email", "description"} {
+				if v, ok := obj[field].(string); ok && strings.Contains(strings.ToLower(v), query) {
+					matched = append(matched, obj)
+					break
+				}
+			}
+		}
+		items = matched
+	}
+	page := make([]interface{}, len(items))
+	for i, obj := range items {
+		page[i] = obj
+	}
+	writePage(w, q, collection, page)
+}
+
+// writePage writes the page of items selected by the offset and limit
+// parameters of q, under key.
+func writePage(w http.ResponseWriter, q url.Values, key string, items []interface{}) {
+	offset, _ := strconv.Atoi(q.Get("offset"))
+	limit, _ := strconv.Atoi(q.Get("limit"))
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+	page := []interface{}{}
+	for i := offset; i < len(items) && i < offset+limit; i++ {
+		page = append(page, items[i])
+	}
+	resp := map[string]interface{}{
+		key:      page,
+		"offset": offset,
+		"limit":  limit,
+		"more":   offset+limit < len(items),
+	}
+	if q.Get("total") == "true" {
+		resp["total"] = len(items)
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) serveBulkUpdate(w http.ResponseWriter, body []byte, collection string) {
+	var payload map[string][]Object
+	if err := json.Unmarshal(body, &payload); err != nil {
+		writeError(w, http.StatusBadRequest, 2001, "Invalid Input Provided")
+		return
+	}
+	updated := []Object{}
+	for _, change := range payload[collection] {
+		_, obj := s.find(collection, change.ID())
+		if obj == nil {
+			writeError(w, http.StatusNotFound, 2100, "Not Found")
+			return
+		}
+		merge(obj, change)
+		updated = append(updated, obj)
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{collection: updated})
+}
+
+func (s *Server) serveItem(w http.ResponseWriter, r *http.Request, body []byte, collection, id string) {
+	key := collections[collection]
+	i, obj := s.find(collection, id)
+	if obj == nil {
+		writeError(w, http.StatusNotFound, 2100, "Not Found")
+		return
+	}
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, map[string]interface{}{key: obj})
+	case http.MethodPut:
+		var payload map[string]Object
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, 2001, "Invalid Input Provided")
+			return
+		}
+		merge(obj, payload[key])
+		writeJSON(w, http.StatusOK, map[string]interface{}{key: obj})
+	case http.MethodDelete:
+		s.items[collection] = append(s.items[collection][:i], s.items[collection][i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 2100, "Method Not Allowed")
+	}
+}
+
+// serveSubresource serves the lists nested in an object, such as the notes of
+// an incident, stored under the key of the same name. When memberID is set,
+// the request adds or removes a member of the list.
+func (s *Server) serveSubresource(w http.ResponseWriter, r *http.Request, body []byte, collection, id, sub, memberID string) {
+	_, obj := s.find(collection, id)
+	if obj == nil {
+		writeError(w, http.StatusNotFound, 2100, "Not Found")
+		return
+	}
+	items, _ := obj[sub].([]interface{})
+	switch {
+	case memberID == "" && r.Method == http.MethodGet:
+		if collection == "teams" && sub == "members" {
+			items = teamMembers(obj)
+		}
+		writePage(w, r.URL.Query(), sub, items)
+	case memberID == "" && r.Method == http.MethodPost:
+		var payload map[string]interface{}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			writeError(w, http.StatusBadRequest, 2001, "Invalid Input Provided")
+			return
+		}
+		var item interface{} = payload
+		if len(payload) == 1 {
+			for _, v := range payload {
+				item = v
+			}
+		}
+		obj[sub] = append(items, item)
+		writeJSON(w, http.StatusCreated, payload)
+	case memberID != "" && r.Method == http.MethodPut:
+		member := Object{}
+		if len(body) > 0 {
+			if err := json.Unmarshal(body, &member); err != nil {
+				writeError(w, http.StatusBadRequest, 2001, "Invalid Input Provided")
+				return
+			}
+		}
+		member["id"] = memberID
+		member["type"] = referenceType(sub)
+		if i := indexOf(items, memberID); i >= 0 {
+			items[i] = member
+		} else {
+			items = append(items, member)
+		}
+		obj[sub] = items
+		w.WriteHeader(http.StatusNoContent)
+	case memberID != "" && r.Method == http.MethodDelete:
+		i := indexOf(items, memberID)
+		if i < 0 {
+			writeError(w, http.StatusNotFound, 2100, "Not Found")
+			return
+		}
+		obj[sub] = append(items[:i], items[i+1:]...)
+		w.WriteHeader(http.StatusNoContent)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, 2100, "Method Not Allowed")
+	}
+}
+
+// teamMembers returns the members of a team, with their role, built from the
+// users added to it.
+func teamMembers(team Object) []interface{} {
+	users, _ := team["users"].([]interface{})
+	members := make([]interface{}, len(users))
+	for i, u := range users {
+		user := asObject(u)
+		members[i] = map[string]interface{}{
+			"user": map[string]interface{}{"id": user.ID(), "type": user["type"]},
+			"role": user["role"],
+		}
+	}
+	return members
+}
+
+// referenceType returns the type of a reference to a member of the list sub,
+// such as user_reference for users.
+func referenceType(sub string) string {
+	if typ, ok := collections[sub]; ok {
+		return typ + "_reference"
+	}
+	return sub + "_reference"
+}
+
+// indexOf returns the position of the member with the given id in items, or
+// -1.
+func indexOf(items []interface{}, id string) int {
+	for i, item := range items {
+		if asObject(item).ID() == id {
+			return i
+		}
+	}
+	return -1
+}
+
+func (s *Server) serveEvent(w http.ResponseWriter, r *http.Request, body []byte) {
+	var event Object
+	if err := json.Unmarshal(body, &event); err != nil || r.Method != http.MethodPost {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"status":  "invalid event",
+			"message": "Event object is invalid",
+			"errors":  []string{"Invalid JSON"},
+		})
+		return
+	}
+	s.events = append(s.events, event)
+	key := fmt.Sprintf("event-%d", len(s.events))
+	if r.URL.Path == "/generic/2010-04-15/create_event.json" {
+		if k, ok := event["incident_key"].(string); ok && k != "" {
+			key = k
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"status":       "success",
+			"message":      "Event processed",
+			"incident_key": key,
+		})
+		return
+	}
+	if k, ok := event["dedup_key"].(string); ok && k != "" {
+		key = k
+	}
+	writeJSON(w, http.StatusAccepted, map[string]interface{}{
+		"status":    "success",
+		"message":   "Event processed",
+		"dedup_key": key,
+	})
+}
+
+// asObject returns v as an Object, or nil when v is not a JSON object.
+func asObject(v interface{}) Object {
+	switch obj := v.(type) {
+	case Object:
+		return obj
+	case map[string]interface{}:
+		return Object(obj)
+	}
+	return nil
+}
+
+// merge copies the fields of change into obj, except for its id.
+func merge(obj, change Object) {
+	for k, v := range change {
+		if k != "id" {
+			obj[k] = v
+		}
+	}
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status, code int, message string) {
+	if message == "" {
+		message = http.StatusText(status)
+	}
+	writeJSON(w, status, map[string]interface{}{
+		"error": map[string]interface{}{"code": code, "message": message},
+	})
+}
@@ -0,0 +1,149 @@
+package pagerdutytest_test
+
+import (
+	"net/http"
+	"testing"
+
+	pagerduty "github.com/kylie-a/go-pagerduty"
+	"github.com/kylie-a/go-pagerduty/pagerdutytest"
+)
+
+func newClient(server *pagerdutytest.Server) *pagerduty.Client {
+	return pagerduty.NewClient("token", pagerduty.WithCustomClient(server.HTTPClient()))
+}
+
+func TestServiceRoundTrip(t *testing.T) {
+	server := pagerdutytest.NewServer()
+	defer server.Close()
+	client := newClient(server)
+
+	created, err := client.CreateService(pagerduty.Service{
+		APIObject: pagerduty.APIObject{Type: pagerduty.ServiceResourceType},
+		Name:      "Checkout",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == "" {
+		t.Fatal("expected the created service to have an id")
+	}
+	created.Description = "Checkout flow"
+	if _, err := client.UpdateService(*created); err != nil {
+		t.Fatal(err)
+	}
+	service, err := client.GetService(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if service.Name != "Checkout" || service.Description != "Checkout flow" {
+		t.Errorf("unexpected service %+v", service)
+	}
+
+	if err := client.DeleteService(created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetService(created.ID); !pagerduty.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	server.AssertRequested(t, http.MethodPut, "/services/"+created.ID)
+}
+
+func TestListPagination(t *testing.T) {
+	server := pagerdutytest.NewServer()
+	defer server.Close()
+	for _, name := range []string{"alice", "bob", "carol", "dave", "erin"} {
+		server.Add("users", map[string]interface{}{"name": name})
+	}
+	client := newClient(server)
+
+	it := client.Users()
+	it.PageSize = 2
+	users, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(users) != 5 || users[4].Name != "erin" {
+		t.Errorf("unexpected users %+v", users)
+	}
+	server.AssertRequestCount(t, 3)
+}
+
+func TestFaults(t *testing.T) {
+	server := pagerdutytest.NewServer()
+	defer server.Close()
+	server.AddFault(pagerdutytest.Fault{
+		Method:     http.MethodGet,
+		Path:       "/incidents",
+		StatusCode: http.StatusTooManyRequests,
+		Message:    "Rate Limit Exceeded",
+		Times:      1,
+	})
+	client := newClient(server)
+
+	if _, err := client.ListIncidents(); !pagerduty.IsRateLimited(err) {
+		t.Errorf("expected a rate limited error, got %v", err)
+	}
+	if _, err := client.ListIncidents(); err != nil {
+		t.Errorf("expected the fault to apply once, got %v", err)
+	}
+}
+
+func TestTokenAndEvents(t *testing.T) {
+	server := pagerdutytest.NewServer()
+	server.Token = "secret"
+	defer server.Close()
+
+	if _, err := newClient(server).ListTeams(); !pagerduty.IsUnauthorized(err) {
+		t.Errorf("expected an unauthorized error, got %v", err)
+	}
+
+	resp, err := pagerduty.CreateEventWithHTTPClient(pagerduty.Event{
+		ServiceKey:  "key",
+		Type:        "trigger",
+		IncidentKey: "disk-full",
+	}, server.HTTPClient())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if resp.IncidentKey != "disk-full" {
+		t.Errorf("unexpected incident key %q", resp.IncidentKey)
+	}
+	if events := server.Events(); len(events) != 1 || events[0]["event_type"] != "trigger" {
+		t.Errorf("unexpected events %v", events)
+	}
+}
+
+func TestTeamMemberships(t *testing.T) {
+	server := pagerdutytest.NewServer()
+	defer server.Close()
+	id := server.Add("teams", map[string]interface{}{"name": "ops"})
+	client := newClient(server)
+
+	if err := client.AddUserToTeamWithRole(id, "PU1", pagerduty.TeamRoleManager); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddEscalationPolicyToTeam(id, "PEP1"); err != nil {
+		t.Fatal(err)
+	}
+	team, _ := server.Get("teams", id)
+	if policies, _ := team["escalation_policies"].([]interface{}); len(policies) != 1 {
+		t.Errorf("unexpected escalation policies %v", team["escalation_policies"])
+	}
+	members, err := client.ListTeamMembers(id)
+	if err != nil || len(members.Members) != 1 || members.Members[0].Role != pagerduty.TeamRoleManager {
+		t.Errorf("unexpected members %+v: %v", members, err)
+	}
+
+	if err := client.RemoveUserFromTeam(id, "PU1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RemoveEscalationPolicyFromTeam(id, "PEP1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.RemoveUserFromTeam(id, "PU1"); !pagerduty.IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	if members, err := client.ListTeamMembers(id); err != nil || len(members.Members) != 0 {
+		t.Errorf("unexpected members %+v: %v", members, err)
+	}
+}
@@ -0,0 +1,64 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+)
+
+// Priority is the priority level of incidents, such as P1 or P2, used to
+// classify incidents by their impact on the business.
+type Priority struct {
+	APIObject
+	Name        string `json:"name,omitempty"`
+	Description string `json:"description,omitempty"`
+}
+
+type PriorityResponse struct {
+	APIResponse
+}
+
+func (r PriorityResponse) GetResource() (Resource, error) {
+	var dest Priority
+	err := r.getResourceFromResponse(&dest)
+	return dest, err
+}
+
+func NewPriorityResponse(resp *http.Response) PriorityResponse {
+	return PriorityResponse{APIResponse{raw: resp, apiType: PriorityResourceType}}
+}
+
+// ListPrioritiesResponse is the data structure returned from calling the
+// ListPriorities API endpoint.
+type ListPrioritiesResponse struct {
+	APIListObject
+	Priorities []Priority `json:"priorities"`
+}
+
+func (list ListPrioritiesResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Priorities))
+	for i, r := range list.Priorities {
+		resources[i] = r
+	}
+	return resources
+}
+
+// ListPriorities lists the priorities of the account, from the highest to the
+// lowest.
+func (c *Client) ListPriorities(opts ...ResourceRequestOptionFunc) (*ListPrioritiesResponse, error) {
+	return c.ListPrioritiesWithContext(context.Background(), opts...)
+}
+
+// ListPrioritiesWithContext is like ListPriorities but takes a context.
+func (c *Client) ListPrioritiesWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListPrioritiesResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, PriorityResourceType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var result ListPrioritiesResponse
+	return &result, deserialize(resp, &result)
+}
+
+// Matches reports whether the priority has the given ID or name.
+func (p Priority) Matches(idOrName string) bool {
+	return p.ID == idOrName || p.Name == idOrName || p.Summary == idOrName
+}
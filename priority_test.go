@@ -0,0 +1,79 @@
+package pagerduty
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestPriorities(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/priorities":
+			w.Write([]byte(`{"priorities": [{"id": "PP1", "type": "priority", "summary": "P1", "name": "P1"}, {"id": "PP2", "type": "priority", "summary": "P2", "name": "P2"}], "more": false}`))
+		case "/incidents":
+			if q := r.URL.Query(); q.Get("since") != "2021-01-01T00:00:00Z" || q.Get("until") != "2021-01-02T00:00:00Z" || q.Get("limit") != "100" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			if r.URL.Query().Get("offset") == "" {
+				w.Write([]byte(`{"incidents": [{"id": "PINC1", "type": "incident", "priority": {"id": "PP1", "type": "priority", "summary": "P1"}}, {"id": "PINC2", "type": "incident"}], "limit": 2, "more": true}`))
+				return
+			}
+			w.Write([]byte(`{"incidents": [{"id": "PINC3", "type": "incident", "priority": {"id": "PP2", "type": "priority", "summary": "P2"}}], "limit": 2, "offset": 2, "more": false}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	priorities, err := client.ListPriorities()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(priorities.Priorities) != 2 || !priorities.Priorities[0].Matches("P1") || !priorities.Priorities[1].Matches("PP2") {
+		t.Errorf("unexpected priorities %+v", priorities)
+	}
+
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	until := since.Add(24 * time.Hour)
+	incidents, err := client.ListIncidentsByPriority([]string{"P1", "PP2"}, since, until, WithOptions(ListIncidentsOptions{Statuses: []string{"triggered"}}))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(incidents) != 2 || incidents[0].ID != "PINC1" || incidents[1].ID != "PINC3" {
+		t.Errorf("unexpected incidents %+v", incidents)
+	}
+}
+
+func TestListIncidentsByPriorityRequiresWindow(t *testing.T) {
+	client := NewClient("123", WithCustomHost("http://127.0.0.1:0"))
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, window := range [][2]time.Time{{}, {since, time.Time{}}, {since, since}} {
+		var verr *ValidationError
+		if _, err := client.ListIncidentsByPriority([]string{"P1"}, window[0], window[1]); !errors.As(err, &verr) {
+			t.Errorf("window %v: expected a validation error, got %v", window, err)
+		}
+	}
+}
+
+func TestListIncidentsByPriorityCapsPages(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Write([]byte(`{"incidents": [{"id": "PINC1", "type": "incident", "priority": {"id": "PP1", "type": "priority", "summary": "P1"}}], "more": true}`))
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	since := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	incidents, err := client.ListIncidentsByPriority([]string{"P1"}, since, since.Add(time.Hour))
+	if err != ErrTooManyIncidents {
+		t.Fatalf("expected ErrTooManyIncidents, got %v", err)
+	}
+	if calls != priorityMaxPages || len(incidents) != priorityMaxPages {
+		t.Errorf("expected %d pages read, got %d calls and %d incidents", priorityMaxPages, calls, len(incidents))
+	}
+}
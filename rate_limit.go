@@ -0,0 +1,131 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+)
+
+// RateLimiter paces the requests sent by a Client. Implementations must be
+// safe for concurrent use, as a single limiter is shared by every goroutine
+// using the client.
+type RateLimiter interface {
+	// Wait blocks until a request may be sent or ctx is done.
+	Wait(ctx context.Context) error
+}
+
+// AdaptiveRateLimiter is a RateLimiter that adjusts itself to the responses
+// the client receives.
+type AdaptiveRateLimiter interface {
+	RateLimiter
+	// Throttled is called when PagerDuty answered with 429 Too Many Requests.
+	Throttled()
+	// Succeeded is called when a request went through.
+	Succeeded()
+}
+
+// TokenBucket is an AdaptiveRateLimiter allowing on average Rate requests per
+// second, with bursts of up to Burst requests. Every 429 response halves the
+// rate, down to a tenth of the configured one, and every successful request
+// then slowly brings it back up.
+type TokenBucket struct {
+	mu      sync.Mutex
+	rate    float64
+	maxRate float64
+	minRate float64
+	burst   float64
+	tokens  float64
+	last    time.Time
+}
+
+// NewTokenBucket creates a TokenBucket allowing rate requests per second with
+// bursts of up to burst requests. It returns an error when rate is not
+// positive; leave the client without a rate limiter to disable rate limiting.
+func NewTokenBucket(rate float64, burst int) (*TokenBucket, error) {
+	if !(rate > 0) {
+		return nil, fmt.Errorf("pagerduty: token bucket rate must be positive, got %v", rate)
+	}
+	if burst < 1 {
+		burst = 1
+	}
+	return &TokenBucket{
+		rate:    rate,
+		maxRate: rate,
+		minRate: rate / 10,
+		burst:   float64(burst),
+		tokens:  float64(burst),
+		last:    time.Now(),
+	}, nil
+}
+
+// Rate returns the number of requests per second currently allowed.
+func (b *TokenBucket) Rate() float64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.rate
+}
+
+// Wait blocks until a token is available or ctx is done.
+func (b *TokenBucket) Wait(ctx context.Context) error {
+	b.mu.Lock()
+	now := time.Now()
+	b.refill(now)
+	b.tokens--
+	var wait time.Duration
+	if b.tokens < 0 {
+		wait = time.Duration(-b.tokens / b.rate * float64(time.Second))
+	}
+	b.mu.Unlock()
+
+	if err := sleepContext(ctx, wait); err != nil {
+		b.mu.Lock()
+		b.tokens++
+		b.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// Throttled halves the allowed rate.
+func (b *TokenBucket) Throttled() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.refill(time.Now())
+	b.rate = math.Max(b.rate/2, b.minRate)
+	if b.tokens > 0 {
+		b.tokens = 0
+	}
+}
+
+// Succeeded raises the allowed rate by a twentieth of the configured one.
+func (b *TokenBucket) Succeeded() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.rate < b.maxRate {
+		b.refill(time.Now())
+		b.rate = math.Min(b.rate+b.maxRate/20, b.maxRate)
+	}
+}
+
+func (b *TokenBucket) refill(now time.Time) {
+	b.tokens = math.Min(b.burst, b.tokens+now.Sub(b.last).Seconds()*b.rate)
+	b.last = now
+}
+
+// WithRateLimiter paces the REST API requests of the client with l.
+func WithRateLimiter(l RateLimiter) NewClientOptionFunc {
+	return func(client *Client) {
+		client.rateLimiter = l
+	}
+}
+
+// WithEventsRateLimiter paces the events sent by the client with l. Events
+// have their own budget on PagerDuty's side, so they use a separate limiter
+// from the REST API.
+func WithEventsRateLimiter(l RateLimiter) NewClientOptionFunc {
+	return func(client *Client) {
+		client.eventsRateLimiter = l
+	}
+}
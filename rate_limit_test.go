@@ -0,0 +1,105 @@
+package pagerduty
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+type countingLimiter struct {
+	mu    sync.Mutex
+	waits int
+}
+
+func (l *countingLimiter) Wait(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.waits++
+	return nil
+}
+
+type statusHTTPClient struct {
+	status int
+	body   string
+}
+
+func (c statusHTTPClient) Do(request *http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: c.status,
+		Header:     http.Header{},
+		Body:       ioutil.NopCloser(strings.NewReader(c.body)),
+	}, nil
+}
+
+func TestTokenBucketPacesRequests(t *testing.T) {
+	b, _ := NewTokenBucket(100, 2)
+	start := time.Now()
+	for i := 0; i < 4; i++ {
+		if err := b.Wait(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 15*time.Millisecond {
+		t.Errorf("4 requests at 100/s with a burst of 2 took only %v", elapsed)
+	}
+}
+
+func TestTokenBucketRejectsNonPositiveRate(t *testing.T) {
+	for _, rate := range []float64{0, -1, math.NaN()} {
+		if b, err := NewTokenBucket(rate, 1); err == nil || b != nil {
+			t.Errorf("expected rate %v to be rejected", rate)
+		}
+	}
+}
+
+func TestTokenBucketWaitHonoursContext(t *testing.T) {
+	b, _ := NewTokenBucket(0.001, 1)
+	b.Wait(context.Background())
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	if err := b.Wait(ctx); err != context.DeadlineExceeded {
+		t.Errorf("expected context.DeadlineExceeded, got %v", err)
+	}
+}
+
+func TestTokenBucketAdaptsToThrottling(t *testing.T) {
+	b, _ := NewTokenBucket(10, 10)
+	client := NewClient("123",
+		WithCustomClient(statusHTTPClient{status: http.StatusTooManyRequests}),
+		WithRateLimiter(b))
+	client.ListUsers()
+	client.ListUsers()
+	if rate := b.Rate(); rate != 2.5 {
+		t.Errorf("expected rate to be halved twice to 2.5, got %v", rate)
+	}
+
+	client.HTTPClient = statusHTTPClient{status: http.StatusOK, body: `{"users": []}`}
+	for i := 0; i < 5; i++ {
+		client.ListUsers()
+	}
+	if rate := b.Rate(); rate != 5 {
+		t.Errorf("expected rate to recover to 5, got %v", rate)
+	}
+}
+
+func TestSeparateEventsRateLimiter(t *testing.T) {
+	rest, events := &countingLimiter{}, &countingLimiter{}
+	client := NewClient("123",
+		WithCustomClient(statusHTTPClient{status: http.StatusOK, body: `{"status": "success"}`}),
+		WithRateLimiter(rest),
+		WithEventsRateLimiter(events))
+
+	client.ListUsers()
+	client.ListUsers()
+	if _, err := client.CreateEvent(Event{Type: "trigger"}); err != nil {
+		t.Fatal(err)
+	}
+	if rest.waits != 2 || events.waits != 1 {
+		t.Errorf("expected 2 REST and 1 events waits, got %d and %d", rest.waits, events.waits)
+	}
+}
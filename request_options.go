@@ -2,6 +2,9 @@ package pagerduty
 
 import (
 	"net/http"
+	"strconv"
+
+	"github.com/google/go-querystring/query"
 )
 
 type ResourceRequestOptionFunc func(*http.Request) error
@@ -13,6 +16,13 @@ func setQueryParam(key, value string, request *http.Request) error {
 	return nil
 }
 
+func replaceQueryParam(key, value string, request *http.Request) error {
+	params := request.URL.Query()
+	params.Set(key, value)
+	request.URL.RawQuery = params.Encode()
+	return nil
+}
+
 func WithParams(p map[string]string) ResourceRequestOptionFunc {
 	return func(request *http.Request) error {
 		params := request.URL.Query()
@@ -24,6 +34,33 @@ func WithParams(p map[string]string) ResourceRequestOptionFunc {
 	}
 }
 
+// WithCursor requests the page starting at the given cursor, as returned in
+// the next_cursor field of the previous page.
+func WithCursor(value string) ResourceRequestOptionFunc {
+	return func(request *http.Request) error {
+		return replaceQueryParam("cursor", value, request)
+	}
+}
+
+// WithOptions adds the query parameters encoded from one of the *Options
+// structs, such as ListIncidentsOptions.
+func WithOptions(o interface{}) ResourceRequestOptionFunc {
+	return func(request *http.Request) error {
+		v, err := query.Values(o)
+		if err != nil {
+			return err
+		}
+		params := request.URL.Query()
+		for key, values := range v {
+			for _, value := range values {
+				params.Add(key, value)
+			}
+		}
+		request.URL.RawQuery = params.Encode()
+		return nil
+	}
+}
+
 func WithDateRange(value string) ResourceRequestOptionFunc {
 	return func(request *http.Request) error {
 		return setQueryParam("date_range", value, request)
@@ -60,6 +97,22 @@ func WithIsOverview(value string) ResourceRequestOptionFunc {
 	}
 }
 
+// WithLimit sets the number of results per page, replacing any limit set by
+// earlier options.
+func WithLimit(value uint) ResourceRequestOptionFunc {
+	return func(request *http.Request) error {
+		return replaceQueryParam("limit", strconv.FormatUint(uint64(value), 10), request)
+	}
+}
+
+// WithOffset sets the offset to start the results at, replacing any offset set
+// by earlier options.
+func WithOffset(value uint) ResourceRequestOptionFunc {
+	return func(request *http.Request) error {
+		return replaceQueryParam("offset", strconv.FormatUint(uint64(value), 10), request)
+	}
+}
+
 func WithOverflow(value string) ResourceRequestOptionFunc {
 	return func(request *http.Request) error {
 		return setQueryParam("overflow", value, request)
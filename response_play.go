@@ -1,6 +1,9 @@
 package pagerduty
 
-import "net/http"
+import (
+	"context"
+	"net/http"
+)
 
 type ResponsePlay struct {
 	APIObject
@@ -22,10 +25,24 @@ func NewResponsePlayResponse(resp *http.Response) ResponsePlayResponse {
 
 type ListResponsePlaysResponse struct {
 	APIListObject
+	ResponsePlays []ResponsePlay `json:"response_plays"`
+}
+
+func (list ListResponsePlaysResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.ResponsePlays))
+	for i, r := range list.ResponsePlays {
+		resources[i] = r
+	}
+	return resources
 }
 
 func (c *Client) GetResponsePlay(id string, opts ...ResourceRequestOptionFunc) (*ResponsePlay, error) {
-	res, err := c.GetResource(ResponsePlayResourceType, id, opts...)
+	return c.GetResponsePlayWithContext(context.Background(), id, opts...)
+}
+
+// GetResponsePlayWithContext is like GetResponsePlay but takes a context.
+func (c *Client) GetResponsePlayWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*ResponsePlay, error) {
+	res, err := c.GetResourceWithContext(ctx, ResponsePlayResourceType, id, opts...)
 	if err != nil {
 	    return nil, err
 	}
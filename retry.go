@@ -0,0 +1,242 @@
+package pagerduty
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy controls how the client retries requests that failed because of
+// rate limiting, a transient server error or a transport error.
+//
+// Requests rejected with 429 Too Many Requests are retried for every method,
+// since PagerDuty did not process them. Server errors and transport errors are
+// only retried for idempotent methods (GET, HEAD, OPTIONS, PUT and DELETE).
+type RetryPolicy struct {
+	// MaxAttempts is the total number of attempts, including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+	// MinBackoff is the base delay before the first retry. The delay doubles
+	// with every further attempt.
+	MinBackoff time.Duration
+	// MaxBackoff caps the computed delay between two attempts. Zero means no
+	// cap.
+	MaxBackoff time.Duration
+	// MaxRetryAfter is the longest delay asked for by the server (through the
+	// Retry-After or ratelimit-reset headers) the client is willing to wait.
+	// When the server asks for more, the response is returned as is.
+	MaxRetryAfter time.Duration
+	// RetryableStatuses are the HTTP status codes that trigger a retry.
+	RetryableStatuses []int
+	// OnRetry, when set, is called before the client waits for the next attempt.
+	OnRetry func(RetryAttempt)
+}
+
+// RetryAttempt describes a failed attempt that is about to be retried.
+type RetryAttempt struct {
+	Method string
+	Path   string
+	// Attempt is the number of the attempt that failed, starting at 1.
+	Attempt int
+	// StatusCode is the status of the failed response, or 0 for a transport error.
+	StatusCode int
+	// Err is the transport error, if any.
+	Err error
+	// Wait is how long the client waits before the next attempt.
+	Wait time.Duration
+}
+
+// DefaultRetryPolicy returns a RetryPolicy retrying 429, 502, 503 and 504
+// responses up to 4 attempts with a jittered exponential backoff.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts:   4,
+		MinBackoff:    500 * time.Millisecond,
+		MaxBackoff:    30 * time.Second,
+		MaxRetryAfter: 2 * time.Minute,
+		RetryableStatuses: []int{
+			http.StatusTooManyRequests,
+			http.StatusBadGateway,
+			http.StatusServiceUnavailable,
+			http.StatusGatewayTimeout,
+		},
+	}
+}
+
+// WithRetryPolicy makes the client retry failed requests according to p.
+func WithRetryPolicy(p RetryPolicy) NewClientOptionFunc {
+	return func(client *Client) {
+		client.retryPolicy = p
+	}
+}
+
+func (p RetryPolicy) retriesStatus(code int) bool {
+	for _, s := range p.RetryableStatuses {
+		if s == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the jittered delay to wait after the given failed attempt.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	d := p.MinBackoff
+	for i := 1; i < attempt && (p.MaxBackoff == 0 || d < p.MaxBackoff) && d < math.MaxInt64/2; i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	if d <= 0 {
+		return 0
+	}
+	half := d / 2
+	return half + time.Duration(rand.Int63n(int64(d-half)+1))
+}
+
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return false
+}
+
+// retryAfter reads the delay requested by the server, if any.
+func retryAfter(resp *http.Response) (time.Duration, bool) {
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return time.Until(t), true
+		}
+	}
+	if v := resp.Header.Get("ratelimit-reset"); v != "" {
+		if secs, err := strconv.Atoi(v); err == nil {
+			return time.Duration(secs) * time.Second, true
+		}
+	}
+	return 0, false
+}
+
+type noRetriesKey struct{}
+
+// withoutRetries returns a context making the client attempt its requests
+// once, whatever its RetryPolicy. It is used by callers retrying on their own.
+func withoutRetries(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noRetriesKey{}, true)
+}
+
+// send performs req, waiting for limiter before every attempt and retrying it
+// according to the client's RetryPolicy.
+func (c *Client) send(req *http.Request, limiter RateLimiter) (*http.Response, error) {
+	p := c.retryPolicy
+	if req.Context().Value(noRetriesKey{}) != nil {
+		p.MaxAttempts = 1
+	}
+	stats := callStatsFromContext(req.Context())
+	for attempt := 1; ; attempt++ {
+		if stats != nil {
+			stats.attempts = attempt
+		}
+		if limiter != nil {
+			if err := limiter.Wait(req.Context()); err != nil {
+				return nil, err
+			}
+		}
+		resp, err := c.HTTPClient.Do(req)
+		if adaptive, ok := limiter.(AdaptiveRateLimiter); ok && err == nil {
+			if resp.StatusCode == http.StatusTooManyRequests {
+				adaptive.Throttled()
+			} else if resp.StatusCode < http.StatusMultipleChoices {
+				adaptive.Succeeded()
+			}
+		}
+		if attempt >= p.MaxAttempts {
+			return resp, attemptsError(attempt, err)
+		}
+
+		retry := false
+		var wait time.Duration
+		var status int
+		switch {
+		case err != nil:
+			retry = isIdempotent(req.Method) && req.Context().Err() == nil
+			wait = p.backoff(attempt)
+		case p.retriesStatus(resp.StatusCode):
+			status = resp.StatusCode
+			retry = status == http.StatusTooManyRequests || isIdempotent(req.Method)
+			wait = p.backoff(attempt)
+			if d, ok := retryAfter(resp); ok {
+				wait = d
+				if wait < 0 {
+					wait = 0
+				}
+				if p.MaxRetryAfter > 0 && wait > p.MaxRetryAfter {
+					retry = false
+				}
+			}
+		}
+		if retry && req.Body != nil {
+			retry = req.GetBody != nil
+		}
+		if !retry {
+			return resp, attemptsError(attempt, err)
+		}
+
+		if resp != nil {
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		if p.OnRetry != nil {
+			p.OnRetry(RetryAttempt{
+				Method:     req.Method,
+				Path:       req.URL.Path,
+				Attempt:    attempt,
+				StatusCode: status,
+				Err:        err,
+				Wait:       wait,
+			})
+		}
+		if err := sleepContext(req.Context(), wait); err != nil {
+			return nil, attemptsError(attempt, err)
+		}
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// attemptsError annotates a transport error with the number of attempts made.
+func attemptsError(attempts int, err error) error {
+	if err == nil || attempts < 2 {
+		return err
+	}
+	return fmt.Errorf("giving up after %d attempts: %w", attempts, err)
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
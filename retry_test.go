@@ -0,0 +1,130 @@
+package pagerduty
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func fastRetryPolicy() RetryPolicy {
+	p := DefaultRetryPolicy()
+	p.MinBackoff = time.Millisecond
+	p.MaxBackoff = 5 * time.Millisecond
+	return p
+}
+
+func TestRetryRewindsBody(t *testing.T) {
+	var bodies []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+		if len(bodies) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"team": {"id": "PT1", "name": "ops"}}`))
+	}))
+	defer server.Close()
+
+	var attempts []RetryAttempt
+	p := fastRetryPolicy()
+	p.OnRetry = func(a RetryAttempt) { attempts = append(attempts, a) }
+	client := NewClient("123", WithCustomHost(server.URL), WithRetryPolicy(p))
+
+	team, err := client.UpdateTeam("PT1", &Team{APIObject: APIObject{ID: "PT1", Type: TeamResourceType}, Name: "ops"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.Name != "ops" {
+		t.Errorf("unexpected team %+v", team)
+	}
+	if len(bodies) != 3 {
+		t.Fatalf("expected 3 attempts, got %d", len(bodies))
+	}
+	for i, b := range bodies {
+		if b != bodies[0] || b == "" {
+			t.Errorf("attempt %d sent body %q, want %q", i+1, b, bodies[0])
+		}
+	}
+	if len(attempts) != 2 || attempts[1].Attempt != 2 || attempts[1].StatusCode != http.StatusServiceUnavailable {
+		t.Errorf("unexpected retry notifications %+v", attempts)
+	}
+}
+
+func TestRetryHonoursRetryAfter(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.Header().Set("Retry-After", "1")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"users": []}`))
+	}))
+	defer server.Close()
+
+	var waits []time.Duration
+	p := fastRetryPolicy()
+	p.OnRetry = func(a RetryAttempt) { waits = append(waits, a.Wait) }
+	client := NewClient("123", WithCustomHost(server.URL), WithRetryPolicy(p))
+
+	if _, err := client.ListUsers(); err != nil {
+		t.Fatal(err)
+	}
+	if len(waits) != 1 || waits[0] != time.Second {
+		t.Errorf("expected a single wait of 1s, got %v", waits)
+	}
+}
+
+func TestRetrySkipsNonIdempotentServerErrors(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	client := NewClient("123", WithCustomHost(server.URL), WithRetryPolicy(fastRetryPolicy()))
+	if err := client.SnoozeIncident("PI1", 60); err == nil {
+		t.Error("expected an error")
+	}
+	if calls != 1 {
+		t.Errorf("POST was attempted %d times, want 1", calls)
+	}
+}
+
+func TestRetryGivesUp(t *testing.T) {
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusGatewayTimeout)
+	}))
+	defer server.Close()
+
+	p := fastRetryPolicy()
+	p.MaxAttempts = 3
+	client := NewClient("123", WithCustomHost(server.URL), WithRetryPolicy(p))
+	if _, err := client.GetUser("PU1"); err == nil {
+		t.Error("expected an error")
+	}
+	if calls != 3 {
+		t.Errorf("expected 3 attempts, got %d", calls)
+	}
+}
+
+func TestRetryBackoffGrowsWithoutCap(t *testing.T) {
+	p := RetryPolicy{MinBackoff: time.Millisecond}
+	if d := p.backoff(5); d < 8*time.Millisecond || d > 16*time.Millisecond {
+		t.Errorf("expected the fifth backoff to be between 8ms and 16ms, got %s", d)
+	}
+	if d := p.backoff(100); d <= 0 {
+		t.Errorf("expected a positive backoff, got %s", d)
+	}
+	p.MaxBackoff = 2 * time.Millisecond
+	if d := p.backoff(5); d > 2*time.Millisecond {
+		t.Errorf("expected the backoff to be capped at 2ms, got %s", d)
+	}
+}
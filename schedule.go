@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -75,6 +76,14 @@ type ListSchedulesResponse struct {
 	Schedules []Schedule `json:"schedules"`
 }
 
+func (list ListSchedulesResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Schedules))
+	for i, r := range list.Schedules {
+		resources[i] = r
+	}
+	return resources
+}
+
 // UserReference is a reference to an authorized PagerDuty user.
 type UserReference struct {
 	User APIObject `json:"user"`
@@ -82,7 +91,12 @@ type UserReference struct {
 
 // ListSchedules lists the on-call schedules.
 func (c *Client) ListSchedules(opts ...ResourceRequestOptionFunc) (*ListSchedulesResponse, error) {
-	resp, err := c.ListResources(ScheduleResourceType, opts...)
+	return c.ListSchedulesWithContext(context.Background(), opts...)
+}
+
+// ListSchedulesWithContext is like ListSchedules but takes a context.
+func (c *Client) ListSchedulesWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListSchedulesResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, ScheduleResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -92,7 +106,12 @@ func (c *Client) ListSchedules(opts ...ResourceRequestOptionFunc) (*ListSchedule
 
 // CreateSchedule creates a new on-call schedule.
 func (c *Client) CreateSchedule(s Schedule) (*Schedule, error) {
-	resp, err := c.CreateResource(s)
+	return c.CreateScheduleWithContext(context.Background(), s)
+}
+
+// CreateScheduleWithContext is like CreateSchedule but takes a context.
+func (c *Client) CreateScheduleWithContext(ctx context.Context, s Schedule) (*Schedule, error) {
+	resp, err := c.CreateResourceWithContext(ctx, s)
 	if err != nil {
 		return nil, err
 	}
@@ -110,19 +129,29 @@ type PreviewScheduleOptions struct {
 
 // PreviewSchedule previews what an on-call schedule would look like without saving it.
 func (c *Client) PreviewSchedule(s Schedule, o PreviewScheduleOptions) error {
+	return c.PreviewScheduleWithContext(context.Background(), s, o)
+}
+
+// PreviewScheduleWithContext is like PreviewSchedule but takes a context.
+func (c *Client) PreviewScheduleWithContext(ctx context.Context, s Schedule, o PreviewScheduleOptions) error {
 	v, err := query.Values(o)
 	if err != nil {
 		return err
 	}
 	var data map[string]Schedule
 	data["schedule"] = s
-	_, e := c.post("/schedules/preview?"+v.Encode(), data)
+	_, e := c.post(ctx, "/schedules/preview?"+v.Encode(), data)
 	return e
 }
 
 // DeleteSchedule deletes an on-call schedule.
 func (c *Client) DeleteSchedule(id string) error {
-	return c.DeleteResource(ScheduleResourceType, id)
+	return c.DeleteScheduleWithContext(context.Background(), id)
+}
+
+// DeleteScheduleWithContext is like DeleteSchedule but takes a context.
+func (c *Client) DeleteScheduleWithContext(ctx context.Context, id string) error {
+	return c.DeleteResourceWithContext(ctx, ScheduleResourceType, id)
 }
 
 // GetScheduleOptions is the data structure used when calling the GetSchedule API endpoint.
@@ -135,7 +164,12 @@ type GetScheduleOptions struct {
 
 // GetSchedule shows detailed information about a schedule, including entries for each layer and sub-schedule.
 func (c *Client) GetSchedule(id string, opts ...ResourceRequestOptionFunc) (*Schedule, error) {
-	res, err := c.GetResource(ScheduleResourceType, id, opts...)
+	return c.GetScheduleWithContext(context.Background(), id, opts...)
+}
+
+// GetScheduleWithContext is like GetSchedule but takes a context.
+func (c *Client) GetScheduleWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*Schedule, error) {
+	res, err := c.GetResourceWithContext(ctx, ScheduleResourceType, id, opts...)
 	if err != nil {
 	    return nil, err
 	}
@@ -150,7 +184,12 @@ type UpdateScheduleOptions struct {
 
 // UpdateSchedule updates an existing on-call schedule.
 func (c *Client) UpdateSchedule(id string, s Schedule) (*Schedule, error) {
-	resp, err := c.UpdateResource(s)
+	return c.UpdateScheduleWithContext(context.Background(), id, s)
+}
+
+// UpdateScheduleWithContext is like UpdateSchedule but takes a context.
+func (c *Client) UpdateScheduleWithContext(ctx context.Context, id string, s Schedule) (*Schedule, error) {
+	resp, err := c.UpdateResourceWithContext(ctx, s)
 	if err != nil {
 		return nil, err
 	}
@@ -178,11 +217,16 @@ type Override struct {
 // TODO: Figure out what the hell this is doing
 // ListOverrides lists overrides for a given time range.
 func (c *Client) ListOverrides(id string, o ListOverridesOptions) ([]Override, error) {
+	return c.ListOverridesWithContext(context.Background(), id, o)
+}
+
+// ListOverridesWithContext is like ListOverrides but takes a context.
+func (c *Client) ListOverridesWithContext(ctx context.Context, id string, o ListOverridesOptions) ([]Override, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get("/schedules/" + id + "/overrides?" + v.Encode())
+	resp, err := c.get(ctx, "/schedules/"+id+"/overrides?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -199,9 +243,14 @@ func (c *Client) ListOverrides(id string, o ListOverridesOptions) ([]Override, e
 
 // CreateOverride creates an override for a specific user covering the specified time range.
 func (c *Client) CreateOverride(id string, o Override) (*Override, error) {
+	return c.CreateOverrideWithContext(context.Background(), id, o)
+}
+
+// CreateOverrideWithContext is like CreateOverride but takes a context.
+func (c *Client) CreateOverrideWithContext(ctx context.Context, id string, o Override) (*Override, error) {
 	data := make(map[string]Override)
 	data["override"] = o
-	resp, err := c.post("/schedules/"+id+"/overrides", data)
+	resp, err := c.post(ctx, "/schedules/"+id+"/overrides", data)
 	if err != nil {
 		return nil, err
 	}
@@ -210,7 +259,12 @@ func (c *Client) CreateOverride(id string, o Override) (*Override, error) {
 
 // DeleteOverride removes an override.
 func (c *Client) DeleteOverride(scheduleID, overrideID string) error {
-	_, err := c.delete("/schedules/" + scheduleID + "/overrides/" + overrideID)
+	return c.DeleteOverrideWithContext(context.Background(), scheduleID, overrideID)
+}
+
+// DeleteOverrideWithContext is like DeleteOverride but takes a context.
+func (c *Client) DeleteOverrideWithContext(ctx context.Context, scheduleID, overrideID string) error {
+	_, err := c.delete(ctx, "/schedules/"+scheduleID+"/overrides/"+overrideID)
 	return err
 }
 
@@ -223,11 +277,16 @@ type ListOnCallUsersOptions struct {
 
 // ListOnCallUsers lists all of the users on call in a given schedule for a given time range.
 func (c *Client) ListOnCallUsers(id string, o ListOnCallUsersOptions) ([]User, error) {
+	return c.ListOnCallUsersWithContext(context.Background(), id, o)
+}
+
+// ListOnCallUsersWithContext is like ListOnCallUsers but takes a context.
+func (c *Client) ListOnCallUsersWithContext(ctx context.Context, id string, o ListOnCallUsersOptions) ([]User, error) {
 	v, err := query.Values(o)
 	if err != nil {
 		return nil, err
 	}
-	resp, err := c.get("/schedules/" + id + "/users?" + v.Encode())
+	resp, err := c.get(ctx, "/schedules/"+id+"/users?"+v.Encode())
 	if err != nil {
 		return nil, err
 	}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
@@ -104,9 +105,22 @@ type ListServiceResponse struct {
 	Services []Service
 }
 
+func (list ListServiceResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Services))
+	for i, r := range list.Services {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListServices lists existing services.
 func (c *Client) ListServices(opts ...ResourceRequestOptionFunc) (*ListServiceResponse, error) {
-	resp, err := c.ListResources(ServiceResourceType, opts...)
+	return c.ListServicesWithContext(context.Background(), opts...)
+}
+
+// ListServicesWithContext is like ListServices but takes a context.
+func (c *Client) ListServicesWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListServiceResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, ServiceResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -121,7 +135,12 @@ type GetServiceOptions struct {
 
 // GetService gets details about an existing service.
 func (c *Client) GetService(id string, opts ...ResourceRequestOptionFunc) (*Service, error) {
-	res, err := c.GetResource(ServiceResourceType, id, opts...)
+	return c.GetServiceWithContext(context.Background(), id, opts...)
+}
+
+// GetServiceWithContext is like GetService but takes a context.
+func (c *Client) GetServiceWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*Service, error) {
+	res, err := c.GetResourceWithContext(ctx, ServiceResourceType, id, opts...)
 	if err != nil {
 	    return nil, err
 	}
@@ -131,7 +150,12 @@ func (c *Client) GetService(id string, opts ...ResourceRequestOptionFunc) (*Serv
 
 // CreateService creates a new service.
 func (c *Client) CreateService(s Service) (*Service, error) {
-	resp, err := c.CreateResource(s)
+	return c.CreateServiceWithContext(context.Background(), s)
+}
+
+// CreateServiceWithContext is like CreateService but takes a context.
+func (c *Client) CreateServiceWithContext(ctx context.Context, s Service) (*Service, error) {
+	resp, err := c.CreateResourceWithContext(ctx, s)
 	if err != nil {
 		return nil, err
 	}
@@ -141,7 +165,12 @@ func (c *Client) CreateService(s Service) (*Service, error) {
 
 // UpdateService updates an existing service.
 func (c *Client) UpdateService(s Service) (*Service, error) {
-	resp, err := c.UpdateResource(s)
+	return c.UpdateServiceWithContext(context.Background(), s)
+}
+
+// UpdateServiceWithContext is like UpdateService but takes a context.
+func (c *Client) UpdateServiceWithContext(ctx context.Context, s Service) (*Service, error) {
+	resp, err := c.UpdateResourceWithContext(ctx, s)
 	if err != nil {
 		return nil, err
 	}
@@ -151,14 +180,24 @@ func (c *Client) UpdateService(s Service) (*Service, error) {
 
 // DeleteService deletes an existing service.
 func (c *Client) DeleteService(id string) error {
-	return c.DeleteResource(ServiceResourceType, id)
+	return c.DeleteServiceWithContext(context.Background(), id)
+}
+
+// DeleteServiceWithContext is like DeleteService but takes a context.
+func (c *Client) DeleteServiceWithContext(ctx context.Context, id string) error {
+	return c.DeleteResourceWithContext(ctx, ServiceResourceType, id)
 }
 
 // CreateIntegration creates a new integration belonging to a service.
 func (c *Client) CreateIntegration(id string, i Integration) (*Integration, error) {
+	return c.CreateIntegrationWithContext(context.Background(), id, i)
+}
+
+// CreateIntegrationWithContext is like CreateIntegration but takes a context.
+func (c *Client) CreateIntegrationWithContext(ctx context.Context, id string, i Integration) (*Integration, error) {
 	data := make(map[string]Integration)
 	data["integration"] = i
-	resp, err := c.post("/services/"+id+"/integrations", data)
+	resp, err := c.post(ctx, "/services/"+id+"/integrations", data)
 	return getIntegrationFromResponse(c, resp, err)
 }
 
@@ -169,23 +208,38 @@ type GetIntegrationOptions struct {
 
 // GetIntegration gets details about an integration belonging to a service.
 func (c *Client) GetIntegration(serviceID, integrationID string, o GetIntegrationOptions) (*Integration, error) {
+	return c.GetIntegrationWithContext(context.Background(), serviceID, integrationID, o)
+}
+
+// GetIntegrationWithContext is like GetIntegration but takes a context.
+func (c *Client) GetIntegrationWithContext(ctx context.Context, serviceID, integrationID string, o GetIntegrationOptions) (*Integration, error) {
 	v, queryErr := query.Values(o)
 	if queryErr != nil {
 		return nil, queryErr
 	}
-	resp, err := c.get("/services/" + serviceID + "/integrations/" + integrationID + "?" + v.Encode())
+	resp, err := c.get(ctx, "/services/"+serviceID+"/integrations/"+integrationID+"?"+v.Encode())
 	return getIntegrationFromResponse(c, resp, err)
 }
 
 // UpdateIntegration updates an integration belonging to a service.
 func (c *Client) UpdateIntegration(serviceID string, i Integration) (*Integration, error) {
-	resp, err := c.put("/services/"+serviceID+"/integrations/"+i.ID, i, nil)
+	return c.UpdateIntegrationWithContext(context.Background(), serviceID, i)
+}
+
+// UpdateIntegrationWithContext is like UpdateIntegration but takes a context.
+func (c *Client) UpdateIntegrationWithContext(ctx context.Context, serviceID string, i Integration) (*Integration, error) {
+	resp, err := c.put(ctx, "/services/"+serviceID+"/integrations/"+i.ID, i)
 	return getIntegrationFromResponse(c, resp, err)
 }
 
 // DeleteIntegration deletes an existing integration.
 func (c *Client) DeleteIntegration(serviceID string, integrationID string) error {
-	_, err := c.delete("/services/" + serviceID + "/integrations/" + integrationID)
+	return c.DeleteIntegrationWithContext(context.Background(), serviceID, integrationID)
+}
+
+// DeleteIntegrationWithContext is like DeleteIntegration but takes a context.
+func (c *Client) DeleteIntegrationWithContext(ctx context.Context, serviceID string, integrationID string) error {
+	_, err := c.delete(ctx, "/services/"+serviceID+"/integrations/"+integrationID)
 	return err
 }
 
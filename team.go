@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -9,6 +10,59 @@ type Team struct {
 	APIObject
 	Name        string `json:"name,omitempty"`
 	Description string `json:"description,omitempty"`
+	// Parent is the team this team is a sub-team of, if any.
+	Parent *APIObject `json:"parent,omitempty"`
+}
+
+// TeamRole is the role of a user in a team.
+type TeamRole string
+
+// Roles of team members.
+const (
+	TeamRoleObserver  TeamRole = "observer"
+	TeamRoleResponder TeamRole = "responder"
+	TeamRoleManager   TeamRole = "manager"
+)
+
+// TeamMember is a user belonging to a team, with their role in the team.
+type TeamMember struct {
+	User APIObject `json:"user"`
+	Role TeamRole  `json:"role"`
+}
+
+func (m TeamMember) GetID() string {
+	return m.User.ID
+}
+
+func (m TeamMember) GetType() APIResourceType {
+	return m.User.Type
+}
+
+func (m TeamMember) GetSummary() string {
+	return m.User.Summary
+}
+
+func (m TeamMember) GetSelf() string {
+	return m.User.Self
+}
+
+func (m TeamMember) GetHTMLURL() string {
+	return m.User.HTMLURL
+}
+
+// ListTeamMembersResponse is the structure used when calling the
+// ListTeamMembers API endpoint.
+type ListTeamMembersResponse struct {
+	APIListObject
+	Members []TeamMember `json:"members"`
+}
+
+func (list ListTeamMembersResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Members))
+	for i, r := range list.Members {
+		resources[i] = r
+	}
+	return resources
 }
 
 type TeamResponse struct {
@@ -31,6 +85,14 @@ type ListTeamResponse struct {
 	Teams []Team
 }
 
+func (list ListTeamResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Teams))
+	for i, r := range list.Teams {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListTeamOptions are the input parameters used when calling the ListTeams API endpoint.
 type ListTeamOptions struct {
 	APIListObject
@@ -39,7 +101,12 @@ type ListTeamOptions struct {
 
 // ListTeams lists teams of your PagerDuty account, optionally filtered by a search query.
 func (c *Client) ListTeams(opts ...ResourceRequestOptionFunc) (*ListTeamResponse, error) {
-	resp, err := c.ListResources(TeamResourceType, opts...)
+	return c.ListTeamsWithContext(context.Background(), opts...)
+}
+
+// ListTeamsWithContext is like ListTeams but takes a context.
+func (c *Client) ListTeamsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListTeamResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, TeamResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -49,7 +116,12 @@ func (c *Client) ListTeams(opts ...ResourceRequestOptionFunc) (*ListTeamResponse
 
 // CreateTeam creates a new team.
 func (c *Client) CreateTeam(t *Team) (*Team, error) {
-	resp, err := c.CreateResource(t)
+	return c.CreateTeamWithContext(context.Background(), t)
+}
+
+// CreateTeamWithContext is like CreateTeam but takes a context.
+func (c *Client) CreateTeamWithContext(ctx context.Context, t *Team) (*Team, error) {
+	resp, err := c.CreateResourceWithContext(ctx, t.withDefaults(""))
 	if err != nil {
 		return nil, err
 	}
@@ -59,12 +131,22 @@ func (c *Client) CreateTeam(t *Team) (*Team, error) {
 
 // DeleteTeam removes an existing team.
 func (c *Client) DeleteTeam(id string) error {
-	return c.DeleteResource(TeamResourceType, id)
+	return c.DeleteTeamWithContext(context.Background(), id)
+}
+
+// DeleteTeamWithContext is like DeleteTeam but takes a context.
+func (c *Client) DeleteTeamWithContext(ctx context.Context, id string) error {
+	return c.DeleteResourceWithContext(ctx, TeamResourceType, id)
 }
 
 // GetTeam gets details about an existing team.
 func (c *Client) GetTeam(id string) (*Team, error) {
-	res, err := c.GetResource(TeamResourceType, id)
+	return c.GetTeamWithContext(context.Background(), id)
+}
+
+// GetTeamWithContext is like GetTeam but takes a context.
+func (c *Client) GetTeamWithContext(ctx context.Context, id string) (*Team, error) {
+	res, err := c.GetResourceWithContext(ctx, TeamResourceType, id)
 	if err != nil {
 	    return nil, err
 	}
@@ -74,7 +156,12 @@ func (c *Client) GetTeam(id string) (*Team, error) {
 
 // UpdateTeam updates an existing team.
 func (c *Client) UpdateTeam(id string, t *Team) (*Team, error) {
-	resp, err := c.UpdateResource(t)
+	return c.UpdateTeamWithContext(context.Background(), id, t)
+}
+
+// UpdateTeamWithContext is like UpdateTeam but takes a context.
+func (c *Client) UpdateTeamWithContext(ctx context.Context, id string, t *Team) (*Team, error) {
+	resp, err := c.UpdateResourceWithContext(ctx, t.withDefaults(id))
 	if err != nil {
 		return nil, err
 	}
@@ -84,24 +171,124 @@ func (c *Client) UpdateTeam(id string, t *Team) (*Team, error) {
 
 // RemoveEscalationPolicyFromTeam removes an escalation policy from a team.
 func (c *Client) RemoveEscalationPolicyFromTeam(teamID, epID string) error {
-	_, err := c.delete("/teams/" + teamID + "/escalation_policies/" + epID)
+	return c.RemoveEscalationPolicyFromTeamWithContext(context.Background(), teamID, epID)
+}
+
+// RemoveEscalationPolicyFromTeamWithContext is like RemoveEscalationPolicyFromTeam but takes a context.
+func (c *Client) RemoveEscalationPolicyFromTeamWithContext(ctx context.Context, teamID, epID string) error {
+	_, err := c.delete(ctx, "/teams/"+teamID+"/escalation_policies/"+epID)
 	return err
 }
 
 // AddEscalationPolicyToTeam adds an escalation policy to a team.
 func (c *Client) AddEscalationPolicyToTeam(teamID, epID string) error {
-	_, err := c.put("/teams/"+teamID+"/escalation_policies/"+epID, nil, nil)
+	return c.AddEscalationPolicyToTeamWithContext(context.Background(), teamID, epID)
+}
+
+// AddEscalationPolicyToTeamWithContext is like AddEscalationPolicyToTeam but takes a context.
+func (c *Client) AddEscalationPolicyToTeamWithContext(ctx context.Context, teamID, epID string) error {
+	_, err := c.put(ctx, "/teams/"+teamID+"/escalation_policies/"+epID, nil)
 	return err
 }
 
 // RemoveUserFromTeam removes a user from a team.
 func (c *Client) RemoveUserFromTeam(teamID, userID string) error {
-	_, err := c.delete("/teams/" + teamID + "/users/" + userID)
+	return c.RemoveUserFromTeamWithContext(context.Background(), teamID, userID)
+}
+
+// RemoveUserFromTeamWithContext is like RemoveUserFromTeam but takes a context.
+func (c *Client) RemoveUserFromTeamWithContext(ctx context.Context, teamID, userID string) error {
+	_, err := c.delete(ctx, "/teams/"+teamID+"/users/"+userID)
 	return err
 }
 
-// AddUserToTeam adds a user to a team.
+// AddUserToTeam adds a user to a team, with the default role of the account.
 func (c *Client) AddUserToTeam(teamID, userID string) error {
-	_, err := c.put("/teams/"+teamID+"/users/"+userID, nil, nil)
+	return c.AddUserToTeamWithContext(context.Background(), teamID, userID)
+}
+
+// AddUserToTeamWithContext is like AddUserToTeam but takes a context.
+func (c *Client) AddUserToTeamWithContext(ctx context.Context, teamID, userID string) error {
+	_, err := c.put(ctx, "/teams/"+teamID+"/users/"+userID, nil)
+	return err
+}
+
+// AddUserToTeamWithRole adds a user to a team with the given role, or changes
+// the role of a member of the team.
+func (c *Client) AddUserToTeamWithRole(teamID, userID string, role TeamRole) error {
+	return c.AddUserToTeamWithRoleWithContext(context.Background(), teamID, userID, role)
+}
+
+// AddUserToTeamWithRoleWithContext is like AddUserToTeamWithRole but takes a
+// context.
+func (c *Client) AddUserToTeamWithRoleWithContext(ctx context.Context, teamID, userID string, role TeamRole) error {
+	switch role {
+	case TeamRoleObserver, TeamRoleResponder, TeamRoleManager:
+	default:
+		return &ValidationError{Field: "role", Reason: "must be observer, responder or manager"}
+	}
+	data := map[string]TeamRole{"role": role}
+	_, err := c.put(ctx, "/teams/"+teamID+"/users/"+userID, data)
 	return err
 }
+
+// ListTeamMembers lists a page of the members of a team, with their role. Use
+// WithLimit and WithOffset to get the other pages, or TeamMembers to iterate
+// over every member.
+func (c *Client) ListTeamMembers(teamID string, opts ...ResourceRequestOptionFunc) (*ListTeamMembersResponse, error) {
+	return c.ListTeamMembersWithContext(context.Background(), teamID, opts...)
+}
+
+// ListTeamMembersWithContext is like ListTeamMembers but takes a context.
+func (c *Client) ListTeamMembersWithContext(ctx context.Context, teamID string, opts ...ResourceRequestOptionFunc) (*ListTeamMembersResponse, error) {
+	resp, err := c.get(ctx, "/teams/"+teamID+"/members", opts...)
+	if err != nil {
+		return nil, err
+	}
+	var result ListTeamMembersResponse
+	return &result, deserialize(resp, &result)
+}
+
+// TeamMemberIterator iterates over the members of a team.
+type TeamMemberIterator struct {
+	*Iterator
+}
+
+// TeamMembers returns an iterator over every member of a team.
+func (c *Client) TeamMembers(teamID string, opts ...ResourceRequestOptionFunc) *TeamMemberIterator {
+	return c.TeamMembersWithContext(context.Background(), teamID, opts...)
+}
+
+// TeamMembersWithContext is like TeamMembers but takes a context.
+func (c *Client) TeamMembersWithContext(ctx context.Context, teamID string, opts ...ResourceRequestOptionFunc) *TeamMemberIterator {
+	return &TeamMemberIterator{newIterator(ctx, func(ctx context.Context, opts ...ResourceRequestOptionFunc) (ResourceList, error) {
+		return c.ListTeamMembersWithContext(ctx, teamID, opts...)
+	}, opts)}
+}
+
+// Member returns the member the iterator is positioned on.
+func (it *TeamMemberIterator) Member() TeamMember {
+	return it.Resource().(TeamMember)
+}
+
+// All returns every remaining member.
+func (it *TeamMemberIterator) All() ([]TeamMember, error) {
+	var all []TeamMember
+	for it.Next() {
+		all = append(all, it.Member())
+	}
+	return all, it.Err()
+}
+
+// withDefaults returns a copy of t with its type set, and its ID set to id
+// when not empty.
+func (t *Team) withDefaults(id string) *Team {
+	team := *t
+	if team.Type == "" {
+		team.Type = TeamResourceType
+	}
+	if id != "" {
+		team.ID = id
+	}
+	return &team
+}
@@ -0,0 +1,110 @@
+package pagerduty
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/kylie-a/go-pagerduty/pagerdutytest"
+)
+
+func TestTeamRoundTrip(t *testing.T) {
+	server := pagerdutytest.NewServer()
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	created, err := client.CreateTeam(&Team{Name: "dba"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if created.ID == "" || created.Type != TeamResourceType {
+		t.Fatalf("unexpected created team %+v", created)
+	}
+	if _, err := client.UpdateTeam(created.ID, &Team{Name: "dba", Description: "Database administrators"}); err != nil {
+		t.Fatal(err)
+	}
+	team, err := client.GetTeam(created.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.Name != "dba" || team.Description != "Database administrators" {
+		t.Errorf("unexpected team %+v", team)
+	}
+	list, err := client.ListTeams()
+	if err != nil || len(list.Teams) != 1 || list.Teams[0].ID != created.ID {
+		t.Errorf("unexpected teams %+v: %v", list, err)
+	}
+
+	if err := client.DeleteTeam(created.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.GetTeam(created.ID); !IsNotFound(err) {
+		t.Errorf("expected a not found error, got %v", err)
+	}
+	var sent map[string]Team
+	if err := server.AssertRequested(t, http.MethodPut, "/teams/"+created.ID).DecodeBody(&sent); err != nil {
+		t.Fatal(err)
+	}
+	if sent["team"].ID != created.ID || sent["team"].Type != TeamResourceType {
+		t.Errorf("unexpected team update %+v", sent["team"])
+	}
+}
+
+func TestTeamMembers(t *testing.T) {
+	server := pagerdutytest.NewServer()
+	defer server.Close()
+	server.Add("teams", map[string]interface{}{"id": "PT1", "name": "ops"})
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	team, err := client.CreateTeam(&Team{Name: "dba", Parent: &APIObject{ID: "PT1", Type: "team_reference"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if team.ID == "" || team.Parent == nil || team.Parent.ID != "PT1" {
+		t.Errorf("unexpected team %+v", team)
+	}
+	var created map[string]Team
+	if err := server.AssertRequested(t, http.MethodPost, "/teams").DecodeBody(&created); err != nil {
+		t.Fatal(err)
+	}
+	if sent := created["team"]; sent.Type != TeamResourceType || sent.Parent.ID != "PT1" {
+		t.Errorf("unexpected team sent %+v", sent)
+	}
+
+	var verr *ValidationError
+	if err := client.AddUserToTeamWithRole("PT1", "PU1", "admin"); !errors.As(err, &verr) {
+		t.Errorf("expected a validation error, got %v", err)
+	}
+	if err := client.AddUserToTeam("PT1", "PU1"); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddUserToTeamWithRole("PT1", "PU1", TeamRoleManager); err != nil {
+		t.Fatal(err)
+	}
+	if err := client.AddUserToTeamWithRole("PT1", "PU2", TeamRoleObserver); err != nil {
+		t.Fatal(err)
+	}
+	var roles []string
+	for _, r := range server.Find(http.MethodPut, "/teams/PT1/users/PU1") {
+		var body map[string]string
+		r.DecodeBody(&body)
+		roles = append(roles, body["role"])
+	}
+	if len(roles) != 2 || roles[0] != "" || roles[1] != "manager" {
+		t.Errorf("unexpected roles sent %v", roles)
+	}
+
+	page, err := client.ListTeamMembers("PT1", WithLimit(1))
+	if err != nil || len(page.Members) != 1 || !page.More {
+		t.Errorf("unexpected first page %+v: %v", page, err)
+	}
+	it := client.TeamMembers("PT1")
+	it.PageSize = 1
+	members, err := it.All()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(members) != 2 || members[0].Role != TeamRoleManager || members[1].GetID() != "PU2" || members[1].Role != TeamRoleObserver {
+		t.Errorf("unexpected members %+v", members)
+	}
+}
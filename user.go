@@ -1,26 +1,97 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Types of contact methods.
+const (
+	EmailContactMethodType = "email_contact_method"
+	PhoneContactMethodType = "phone_contact_method"
+	SMSContactMethodType   = "sms_contact_method"
+	PushContactMethodType  = "push_notification_contact_method"
 )
 
 // ContactMethod is a way of contacting the user.
 type ContactMethod struct {
-	ID             string
-	Label          string
-	Address        string
-	Type           string
-	SendShortEmail bool `json:"send_short_email"`
+	ID      string `json:"id,omitempty"`
+	Summary string `json:"summary,omitempty"`
+	Label   string `json:"label,omitempty"`
+	// Address is the email address, the phone number without its country
+	// code, or the device token of a push contact method.
+	Address string `json:"address,omitempty"`
+	// Type is one of EmailContactMethodType, PhoneContactMethodType,
+	// SMSContactMethodType or PushContactMethodType.
+	Type string `json:"type"`
+	// SendShortEmail only applies to email contact methods.
+	SendShortEmail bool `json:"send_short_email,omitempty"`
+	// CountryCode only applies to phone and SMS contact methods.
+	CountryCode int `json:"country_code,omitempty"`
+	// Blacklisted is set by PagerDuty on phone and SMS contact methods it
+	// cannot reach.
+	Blacklisted bool `json:"blacklisted,omitempty"`
+	// DeviceType only applies to push contact methods: "android" or "ios".
+	DeviceType string `json:"device_type,omitempty"`
+}
+
+// EmailContactMethod returns an email contact method.
+func EmailContactMethod(label, address string) ContactMethod {
+	return ContactMethod{Type: EmailContactMethodType, Label: label, Address: address}
+}
+
+// PhoneContactMethod returns a contact method calling a phone number.
+func PhoneContactMethod(label string, countryCode int, number string) ContactMethod {
+	return ContactMethod{Type: PhoneContactMethodType, Label: label, CountryCode: countryCode, Address: number}
+}
+
+// SMSContactMethod returns a contact method texting a phone number.
+func SMSContactMethod(label string, countryCode int, number string) ContactMethod {
+	return ContactMethod{Type: SMSContactMethodType, Label: label, CountryCode: countryCode, Address: number}
+}
+
+// PushContactMethod returns a contact method sending push notifications to
+// a device of the PagerDuty mobile app.
+func PushContactMethod(label, deviceType, token string) ContactMethod {
+	return ContactMethod{Type: PushContactMethodType, Label: label, DeviceType: deviceType, Address: token}
+}
+
+// Validate checks that the contact method has the fields its type requires.
+func (cm ContactMethod) Validate() error {
+	switch cm.Type {
+	case EmailContactMethodType:
+	case PhoneContactMethodType, SMSContactMethodType:
+		if cm.CountryCode == 0 {
+			return &ValidationError{Field: "country_code", Reason: "is required for " + cm.Type}
+		}
+	case PushContactMethodType:
+		if cm.DeviceType == "" {
+			return &ValidationError{Field: "device_type", Reason: "is required for " + cm.Type}
+		}
+	default:
+		return &ValidationError{Field: "type", Reason: "must be a known contact method type"}
+	}
+	if cm.Address == "" {
+		return &ValidationError{Field: "address", Reason: "is required"}
+	}
+	return nil
 }
 
 // NotificationRule is a rule for notifying the user.
 type NotificationRule struct {
-	ID                  string
-	StartDelayInMinutes uint          `json:"start_delay_in_minutes"`
-	CreatedAt           string        `json:"created_at"`
-	ContactMethod       ContactMethod `json:"contact_method"`
-	Urgency             string
-	Type                string
+	ID                  string `json:"id,omitempty"`
+	StartDelayInMinutes uint   `json:"start_delay_in_minutes"`
+	CreatedAt           string `json:"created_at,omitempty"`
+	// ContactMethod is the contact method notified. Its ID and Type are
+	// enough to create or update a rule.
+	ContactMethod ContactMethod `json:"contact_method"`
+	// Urgency is the urgency of the incidents the rule applies to: "high" or
+	// "low".
+	Urgency string `json:"urgency"`
+	Type    string `json:"type"`
 }
 
 // User is a member of a PagerDuty account that has the ability to interact with incidents and other data on the account.
@@ -60,6 +131,14 @@ type ListUsersResponse struct {
 	Users []User
 }
 
+func (list ListUsersResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Users))
+	for i, r := range list.Users {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListUsersOptions is the data structure used when calling the ListUsers API endpoint.
 type ListUsersOptions struct {
 	APIListObject
@@ -75,7 +154,12 @@ type GetUserOptions struct {
 
 // ListUsers lists users of your PagerDuty account, optionally filtered by a search query.
 func (c *Client) ListUsers(opts ...ResourceRequestOptionFunc) (*ListUsersResponse, error) {
-	resp, err := c.ListResources(UserResourceType, opts...)
+	return c.ListUsersWithContext(context.Background(), opts...)
+}
+
+// ListUsersWithContext is like ListUsers but takes a context.
+func (c *Client) ListUsersWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListUsersResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, UserResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +169,12 @@ func (c *Client) ListUsers(opts ...ResourceRequestOptionFunc) (*ListUsersRespons
 
 // CreateUser creates a new user.
 func (c *Client) CreateUser(u User) (*User, error) {
-	resp, err := c.CreateResource(u)
+	return c.CreateUserWithContext(context.Background(), u)
+}
+
+// CreateUserWithContext is like CreateUser but takes a context.
+func (c *Client) CreateUserWithContext(ctx context.Context, u User) (*User, error) {
+	resp, err := c.CreateResourceWithContext(ctx, u)
 	if err != nil {
 		return nil, err
 	}
@@ -95,12 +184,22 @@ func (c *Client) CreateUser(u User) (*User, error) {
 
 // DeleteUser deletes a user.
 func (c *Client) DeleteUser(id string) error {
-	return c.DeleteResource(UserResourceType, id)
+	return c.DeleteUserWithContext(context.Background(), id)
+}
+
+// DeleteUserWithContext is like DeleteUser but takes a context.
+func (c *Client) DeleteUserWithContext(ctx context.Context, id string) error {
+	return c.DeleteResourceWithContext(ctx, UserResourceType, id)
 }
 
 // GetUser gets details about an existing user.
 func (c *Client) GetUser(id string, opts ...ResourceRequestOptionFunc) (*User, error) {
-	res, err := c.GetResource(UserResourceType, id, opts...)
+	return c.GetUserWithContext(context.Background(), id, opts...)
+}
+
+// GetUserWithContext is like GetUser but takes a context.
+func (c *Client) GetUserWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*User, error) {
+	res, err := c.GetResourceWithContext(ctx, UserResourceType, id, opts...)
 	if err != nil {
 	    return nil, err
 	}
@@ -110,10 +209,336 @@ func (c *Client) GetUser(id string, opts ...ResourceRequestOptionFunc) (*User, e
 
 // UpdateUser updates an existing user.
 func (c *Client) UpdateUser(u User) (*User, error) {
-	resp, err := c.UpdateResource(u)
+	return c.UpdateUserWithContext(context.Background(), u)
+}
+
+// UpdateUserWithContext is like UpdateUser but takes a context.
+func (c *Client) UpdateUserWithContext(ctx context.Context, u User) (*User, error) {
+	resp, err := c.UpdateResourceWithContext(ctx, u)
 	if err != nil {
 		return nil, err
 	}
 	user := resp.(User)
 	return &user, nil
 }
+
+// GetCurrentUser gets details about the user the client's API token belongs
+// to. It fails for account-level API tokens, which belong to no user.
+func (c *Client) GetCurrentUser(opts ...ResourceRequestOptionFunc) (*User, error) {
+	return c.GetCurrentUserWithContext(context.Background(), opts...)
+}
+
+// GetCurrentUserWithContext is like GetCurrentUser but takes a context.
+func (c *Client) GetCurrentUserWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*User, error) {
+	return c.GetUserWithContext(ctx, "me", opts...)
+}
+
+// UserSession is a session of a user logged in to PagerDuty, in a browser or
+// in the mobile app.
+type UserSession struct {
+	ID        string `json:"id"`
+	UserID    string `json:"user_id"`
+	CreatedAt string `json:"created_at"`
+	Type      string `json:"type"`
+	Summary   string `json:"summary"`
+}
+
+// ListUserSessions lists the active sessions of a user.
+func (c *Client) ListUserSessions(userID string) ([]UserSession, error) {
+	return c.ListUserSessionsWithContext(context.Background(), userID)
+}
+
+// ListUserSessionsWithContext is like ListUserSessions but takes a context.
+func (c *Client) ListUserSessionsWithContext(ctx context.Context, userID string) ([]UserSession, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/sessions")
+	if err != nil {
+		return nil, err
+	}
+	var result []UserSession
+	if err := deserializeField(resp, "user_sessions", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// RevokeUserSession logs a user out of one of their sessions.
+func (c *Client) RevokeUserSession(userID string, session UserSession) error {
+	return c.RevokeUserSessionWithContext(context.Background(), userID, session)
+}
+
+// RevokeUserSessionWithContext is like RevokeUserSession but takes a context.
+func (c *Client) RevokeUserSessionWithContext(ctx context.Context, userID string, session UserSession) error {
+	resp, err := c.delete(ctx, "/users/"+userID+"/sessions/"+session.Type+"/"+session.ID)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// RevokeUserSessions logs a user out of every session.
+func (c *Client) RevokeUserSessions(userID string) error {
+	return c.RevokeUserSessionsWithContext(context.Background(), userID)
+}
+
+// RevokeUserSessionsWithContext is like RevokeUserSessions but takes a context.
+func (c *Client) RevokeUserSessionsWithContext(ctx context.Context, userID string) error {
+	resp, err := c.delete(ctx, "/users/"+userID+"/sessions")
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// UserOnCallShifts are the on-call shifts of a user.
+type UserOnCallShifts struct {
+	// Current are the on-calls the user is on now, for each escalation policy
+	// and level.
+	Current []OnCall
+	// Next are the on-calls of the user starting later, soonest first.
+	Next []OnCall
+}
+
+// GetUserOnCallShifts gets the shifts a user is on call for now, and the
+// shifts starting within the given duration. PagerDuty does not return
+// on-calls more than 90 days ahead.
+func (c *Client) GetUserOnCallShifts(userID string, within time.Duration) (*UserOnCallShifts, error) {
+	return c.GetUserOnCallShiftsWithContext(context.Background(), userID, within)
+}
+
+// GetUserOnCallShiftsWithContext is like GetUserOnCallShifts but takes a
+// context.
+func (c *Client) GetUserOnCallShiftsWithContext(ctx context.Context, userID string, within time.Duration) (*UserOnCallShifts, error) {
+	now := time.Now()
+	o := ListOnCallOptions{
+		UserIDs: []string{userID},
+		Since:   now.Format(time.RFC3339),
+		Until:   now.Add(within).Format(time.RFC3339),
+	}
+	it := c.IterateWithContext(ctx, OnCallResourceType, WithOptions(o))
+	var shifts UserOnCallShifts
+	for it.Next() {
+		oc := it.Resource().(OnCall)
+		// On-calls of escalation rules targeting the user directly have no
+		// start: the user is always on call.
+		start, err := time.Parse(time.RFC3339, oc.Start)
+		if oc.Start == "" || err == nil && !start.After(now) {
+			shifts.Current = append(shifts.Current, oc)
+		} else {
+			shifts.Next = append(shifts.Next, oc)
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	sort.SliceStable(shifts.Next, func(i, j int) bool {
+		a, _ := time.Parse(time.RFC3339, shifts.Next[i].Start)
+		b, _ := time.Parse(time.RFC3339, shifts.Next[j].Start)
+		return a.Before(b)
+	})
+	return &shifts, nil
+}
+
+// ListUserContactMethods lists the contact methods of a user.
+func (c *Client) ListUserContactMethods(userID string) ([]ContactMethod, error) {
+	return c.ListUserContactMethodsWithContext(context.Background(), userID)
+}
+
+// ListUserContactMethodsWithContext is like ListUserContactMethods but takes a
+// context.
+func (c *Client) ListUserContactMethodsWithContext(ctx context.Context, userID string) ([]ContactMethod, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/contact_methods")
+	if err != nil {
+		return nil, err
+	}
+	var result []ContactMethod
+	if err := deserializeField(resp, "contact_methods", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUserContactMethod gets details about a contact method of a user.
+func (c *Client) GetUserContactMethod(userID, id string) (*ContactMethod, error) {
+	return c.GetUserContactMethodWithContext(context.Background(), userID, id)
+}
+
+// GetUserContactMethodWithContext is like GetUserContactMethod but takes a
+// context.
+func (c *Client) GetUserContactMethodWithContext(ctx context.Context, userID, id string) (*ContactMethod, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/contact_methods/"+id)
+	return getContactMethodFromResponse(resp, err)
+}
+
+// CreateUserContactMethod adds a contact method to a user.
+func (c *Client) CreateUserContactMethod(userID string, cm ContactMethod) (*ContactMethod, error) {
+	return c.CreateUserContactMethodWithContext(context.Background(), userID, cm)
+}
+
+// CreateUserContactMethodWithContext is like CreateUserContactMethod but takes
+// a context.
+func (c *Client) CreateUserContactMethodWithContext(ctx context.Context, userID string, cm ContactMethod) (*ContactMethod, error) {
+	if err := cm.Validate(); err != nil {
+		return nil, err
+	}
+	data := map[string]ContactMethod{"contact_method": cm}
+	resp, err := c.post(ctx, "/users/"+userID+"/contact_methods", data)
+	return getContactMethodFromResponse(resp, err)
+}
+
+// UpdateUserContactMethod updates an existing contact method of a user.
+func (c *Client) UpdateUserContactMethod(userID string, cm ContactMethod) (*ContactMethod, error) {
+	return c.UpdateUserContactMethodWithContext(context.Background(), userID, cm)
+}
+
+// UpdateUserContactMethodWithContext is like UpdateUserContactMethod but takes
+// a context.
+func (c *Client) UpdateUserContactMethodWithContext(ctx context.Context, userID string, cm ContactMethod) (*ContactMethod, error) {
+	if err := cm.Validate(); err != nil {
+		return nil, err
+	}
+	data := map[string]ContactMethod{"contact_method": cm}
+	resp, err := c.put(ctx, "/users/"+userID+"/contact_methods/"+cm.ID, data)
+	return getContactMethodFromResponse(resp, err)
+}
+
+// DeleteUserContactMethod removes a contact method from a user.
+func (c *Client) DeleteUserContactMethod(userID, id string) error {
+	return c.DeleteUserContactMethodWithContext(context.Background(), userID, id)
+}
+
+// DeleteUserContactMethodWithContext is like DeleteUserContactMethod but takes
+// a context.
+func (c *Client) DeleteUserContactMethodWithContext(ctx context.Context, userID, id string) error {
+	resp, err := c.delete(ctx, "/users/"+userID+"/contact_methods/"+id)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// ListUserNotificationRules lists the notification rules of a user.
+func (c *Client) ListUserNotificationRules(userID string) ([]NotificationRule, error) {
+	return c.ListUserNotificationRulesWithContext(context.Background(), userID)
+}
+
+// ListUserNotificationRulesWithContext is like ListUserNotificationRules but
+// takes a context.
+func (c *Client) ListUserNotificationRulesWithContext(ctx context.Context, userID string) ([]NotificationRule, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/notification_rules")
+	if err != nil {
+		return nil, err
+	}
+	var result []NotificationRule
+	if err := deserializeField(resp, "notification_rules", &result); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GetUserNotificationRule gets details about a notification rule of a user.
+func (c *Client) GetUserNotificationRule(userID, id string) (*NotificationRule, error) {
+	return c.GetUserNotificationRuleWithContext(context.Background(), userID, id)
+}
+
+// GetUserNotificationRuleWithContext is like GetUserNotificationRule but takes
+// a context.
+func (c *Client) GetUserNotificationRuleWithContext(ctx context.Context, userID, id string) (*NotificationRule, error) {
+	resp, err := c.get(ctx, "/users/"+userID+"/notification_rules/"+id)
+	return getNotificationRuleFromResponse(resp, err)
+}
+
+// CreateUserNotificationRule adds a notification rule to a user.
+func (c *Client) CreateUserNotificationRule(userID string, rule NotificationRule) (*NotificationRule, error) {
+	return c.CreateUserNotificationRuleWithContext(context.Background(), userID, rule)
+}
+
+// CreateUserNotificationRuleWithContext is like CreateUserNotificationRule but
+// takes a context.
+func (c *Client) CreateUserNotificationRuleWithContext(ctx context.Context, userID string, rule NotificationRule) (*NotificationRule, error) {
+	if rule.ContactMethod.ID == "" {
+		return nil, &ValidationError{Field: "contact_method.id", Reason: "is required"}
+	}
+	rule, err := rule.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]NotificationRule{"notification_rule": rule}
+	resp, err := c.post(ctx, "/users/"+userID+"/notification_rules", data)
+	return getNotificationRuleFromResponse(resp, err)
+}
+
+// UpdateUserNotificationRule updates an existing notification rule of a user.
+func (c *Client) UpdateUserNotificationRule(userID string, rule NotificationRule) (*NotificationRule, error) {
+	return c.UpdateUserNotificationRuleWithContext(context.Background(), userID, rule)
+}
+
+// UpdateUserNotificationRuleWithContext is like UpdateUserNotificationRule but
+// takes a context.
+func (c *Client) UpdateUserNotificationRuleWithContext(ctx context.Context, userID string, rule NotificationRule) (*NotificationRule, error) {
+	rule, err := rule.withDefaults()
+	if err != nil {
+		return nil, err
+	}
+	data := map[string]NotificationRule{"notification_rule": rule}
+	resp, err := c.put(ctx, "/users/"+userID+"/notification_rules/"+rule.ID, data)
+	return getNotificationRuleFromResponse(resp, err)
+}
+
+// DeleteUserNotificationRule removes a notification rule from a user.
+func (c *Client) DeleteUserNotificationRule(userID, id string) error {
+	return c.DeleteUserNotificationRuleWithContext(context.Background(), userID, id)
+}
+
+// DeleteUserNotificationRuleWithContext is like DeleteUserNotificationRule but
+// takes a context.
+func (c *Client) DeleteUserNotificationRuleWithContext(ctx context.Context, userID, id string) error {
+	resp, err := c.delete(ctx, "/users/"+userID+"/notification_rules/"+id)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// withDefaults sets the type and urgency of rule when missing, and keeps only
+// the reference to its contact method. The type of a contact method, such as
+// sms_contact_method, is turned into the type of its reference,
+// sms_contact_method_reference.
+func (rule NotificationRule) withDefaults() (NotificationRule, error) {
+	if rule.Type == "" {
+		rule.Type = "assignment_notification_rule"
+	}
+	if rule.Urgency == "" {
+		rule.Urgency = "high"
+	}
+	typ := rule.ContactMethod.Type
+	if typ == "" {
+		return rule, &ValidationError{Field: "contact_method.type", Reason: "is required"}
+	}
+	if !strings.HasSuffix(typ, "_reference") {
+		typ += "_reference"
+	}
+	rule.ContactMethod = ContactMethod{ID: rule.ContactMethod.ID, Type: typ}
+	return rule, nil
+}
+
+func getContactMethodFromResponse(resp *http.Response, err error) (*ContactMethod, error) {
+	if err != nil {
+		return nil, err
+	}
+	var cm ContactMethod
+	if err := deserializeField(resp, "contact_method", &cm); err != nil {
+		return nil, err
+	}
+	return &cm, nil
+}
+
+func getNotificationRuleFromResponse(resp *http.Response, err error) (*NotificationRule, error) {
+	if err != nil {
+		return nil, err
+	}
+	var rule NotificationRule
+	if err := deserializeField(resp, "notification_rule", &rule); err != nil {
+		return nil, err
+	}
+	return &rule, nil
+}
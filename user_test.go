@@ -0,0 +1,157 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestUserContactMethodsAndNotificationRules(t *testing.T) {
+	var requests []string
+	var bodies []map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		if r.Method == http.MethodPost || r.Method == http.MethodPut {
+			var body map[string]json.RawMessage
+			json.NewDecoder(r.Body).Decode(&body)
+			bodies = append(bodies, body)
+		}
+		switch r.Method + " " + r.URL.Path {
+		case "GET /users/PU1/contact_methods":
+			w.Write([]byte(`{"contact_methods": [{"id": "PC1", "type": "email_contact_method", "address": "jane@example.com"}]}`))
+		case "POST /users/PU1/contact_methods":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"contact_method": {"id": "PC2", "type": "sms_contact_method", "country_code": 1, "address": "5555550100"}}`))
+		case "PUT /users/PU1/contact_methods/PC2":
+			w.Write([]byte(`{"contact_method": {"id": "PC2", "type": "sms_contact_method", "label": "Mobile"}}`))
+		case "DELETE /users/PU1/contact_methods/PC2", "DELETE /users/PU1/notification_rules/PNR1":
+			w.WriteHeader(http.StatusNoContent)
+		case "POST /users/PU1/notification_rules":
+			w.WriteHeader(http.StatusCreated)
+			w.Write([]byte(`{"notification_rule": {"id": "PNR1", "type": "assignment_notification_rule", "start_delay_in_minutes": 5, "urgency": "high", "contact_method": {"id": "PC2", "type": "sms_contact_method"}}}`))
+		case "GET /users/PU1/notification_rules":
+			w.Write([]byte(`{"notification_rules": [{"id": "PNR1", "urgency": "high"}]}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	methods, err := client.ListUserContactMethods("PU1")
+	if err != nil || len(methods) != 1 || methods[0].Address != "jane@example.com" {
+		t.Errorf("unexpected contact methods %+v: %v", methods, err)
+	}
+	var verr *ValidationError
+	if _, err := client.CreateUserContactMethod("PU1", SMSContactMethod("Mobile", 0, "5555550100")); !errors.As(err, &verr) || verr.Field != "country_code" {
+		t.Errorf("expected a country code validation error, got %v", err)
+	}
+	sms, err := client.CreateUserContactMethod("PU1", SMSContactMethod("Mobile", 1, "5555550100"))
+	if err != nil || sms.ID != "PC2" {
+		t.Fatalf("unexpected contact method %+v: %v", sms, err)
+	}
+	if _, err := client.UpdateUserContactMethod("PU1", ContactMethod{ID: "PC2", Type: SMSContactMethodType, Address: "5555550100"}); !errors.As(err, &verr) || verr.Field != "country_code" {
+		t.Errorf("expected a country code validation error on update, got %v", err)
+	}
+	if updated, err := client.UpdateUserContactMethod("PU1", *sms); err != nil || updated.Label != "Mobile" {
+		t.Errorf("unexpected updated contact method %+v: %v", updated, err)
+	}
+
+	if _, err := client.CreateUserNotificationRule("PU1", NotificationRule{ContactMethod: ContactMethod{ID: "PC2"}}); !errors.As(err, &verr) || verr.Field != "contact_method.type" {
+		t.Errorf("expected a contact method type validation error, got %v", err)
+	}
+	rule, err := client.CreateUserNotificationRule("PU1", NotificationRule{ContactMethod: *sms, StartDelayInMinutes: 5})
+	if err != nil || rule.ID != "PNR1" || rule.ContactMethod.ID != "PC2" {
+		t.Errorf("unexpected notification rule %+v: %v", rule, err)
+	}
+	if sent := string(bodies[2]["notification_rule"]); sent != `{"start_delay_in_minutes":5,"contact_method":{"id":"PC2","type":"sms_contact_method_reference"},"urgency":"high","type":"assignment_notification_rule"}` {
+		t.Errorf("unexpected notification rule sent %s", sent)
+	}
+	if rules, err := client.ListUserNotificationRules("PU1"); err != nil || len(rules) != 1 {
+		t.Errorf("unexpected notification rules %+v: %v", rules, err)
+	}
+	if err := client.DeleteUserNotificationRule("PU1", "PNR1"); err != nil {
+		t.Error(err)
+	}
+	if err := client.DeleteUserContactMethod("PU1", "PC2"); err != nil {
+		t.Error(err)
+	}
+	if len(requests) != 7 {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestCurrentUserAndSessions(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch r.Method + " " + r.URL.Path {
+		case "GET /users/me":
+			w.Write([]byte(`{"user": {"id": "PU1", "type": "user", "name": "Jane", "email": "jane@example.com"}}`))
+		case "GET /users/PU1/sessions":
+			w.Write([]byte(`{"user_sessions": [{"id": "S1", "user_id": "PU1", "type": "browser"}]}`))
+		case "DELETE /users/PU1/sessions/browser/S1", "DELETE /users/PU1/sessions":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	me, err := client.GetCurrentUser()
+	if err != nil || me.ID != "PU1" || me.Email != "jane@example.com" {
+		t.Fatalf("unexpected current user %+v: %v", me, err)
+	}
+	sessions, err := client.ListUserSessions(me.ID)
+	if err != nil || len(sessions) != 1 {
+		t.Fatalf("unexpected sessions %+v: %v", sessions, err)
+	}
+	if err := client.RevokeUserSession(me.ID, sessions[0]); err != nil {
+		t.Error(err)
+	}
+	if err := client.RevokeUserSessions(me.ID); err != nil {
+		t.Error(err)
+	}
+	if len(requests) != 4 {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestGetUserOnCallShifts(t *testing.T) {
+	now := time.Now()
+	at := func(d time.Duration) string { return now.Add(d).Format(time.RFC3339) }
+	var query string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oncalls" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+		query = r.URL.RawQuery
+		json.NewEncoder(w).Encode(ListOnCallsResponse{OnCalls: []OnCall{
+			{EscalationPolicy: APIObject{ID: "PEP1"}, Start: at(48 * time.Hour), End: at(72 * time.Hour)},
+			{EscalationPolicy: APIObject{ID: "PEP2"}},
+			{EscalationPolicy: APIObject{ID: "PEP3"}, Start: at(-time.Hour), End: at(time.Hour)},
+			{EscalationPolicy: APIObject{ID: "PEP4"}, Start: at(24 * time.Hour), End: at(48 * time.Hour)},
+		}})
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	shifts, err := client.GetUserOnCallShifts("PU1", 7*24*time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shifts.Current) != 2 || shifts.Current[0].EscalationPolicy.ID != "PEP2" || shifts.Current[1].EscalationPolicy.ID != "PEP3" {
+		t.Errorf("unexpected current shifts %+v", shifts.Current)
+	}
+	if len(shifts.Next) != 2 || shifts.Next[0].EscalationPolicy.ID != "PEP4" {
+		t.Errorf("unexpected next shifts %+v", shifts.Next)
+	}
+	if v, _ := url.ParseQuery(query); v.Get("user_ids[]") != "PU1" || v.Get("until") == "" {
+		t.Errorf("unexpected query %s", query)
+	}
+}
@@ -1,6 +1,7 @@
 package pagerduty
 
 import (
+	"context"
 	"net/http"
 )
 
@@ -39,6 +40,14 @@ type ListVendorResponse struct {
 	Vendors []Vendor
 }
 
+func (list ListVendorResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Vendors))
+	for i, r := range list.Vendors {
+		resources[i] = r
+	}
+	return resources
+}
+
 // ListVendorOptions is the data structure used when calling the ListVendors API endpoint.
 type ListVendorOptions struct {
 	APIListObject
@@ -47,7 +56,12 @@ type ListVendorOptions struct {
 
 // ListVendors lists existing vendors.
 func (c *Client) ListVendors(opts ...ResourceRequestOptionFunc) (*ListVendorResponse, error) {
-	resp, err := c.ListResources(VendorResourceType, opts...)
+	return c.ListVendorsWithContext(context.Background(), opts...)
+}
+
+// ListVendorsWithContext is like ListVendors but takes a context.
+func (c *Client) ListVendorsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListVendorResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, VendorResourceType, opts...)
 	if err != nil {
 		return nil, err
 	}
@@ -57,7 +71,12 @@ func (c *Client) ListVendors(opts ...ResourceRequestOptionFunc) (*ListVendorResp
 
 // GetVendor gets details about an existing vendor.
 func (c *Client) GetVendor(id string, opts ...ResourceRequestOptionFunc) (*Vendor, error) {
-	res, err := c.GetResource(VendorResourceType, id, opts...)
+	return c.GetVendorWithContext(context.Background(), id, opts...)
+}
+
+// GetVendorWithContext is like GetVendor but takes a context.
+func (c *Client) GetVendorWithContext(ctx context.Context, id string, opts ...ResourceRequestOptionFunc) (*Vendor, error) {
+	res, err := c.GetResourceWithContext(ctx, VendorResourceType, id, opts...)
 	if err != nil {
 	    return nil, err
 	}
@@ -1,9 +1,17 @@
 package pagerduty
 
 import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
+	"strings"
 )
 
 // IncidentDetail contains a representation of the incident associated with the action that caused this webhook message.
@@ -37,6 +45,88 @@ func DecodeWebhook(r io.Reader) (*WebhookPayload, error) {
 	return &payload, nil
 }
 
+// WebhookEventType is the type of event a V2 webhook message reports.
+type WebhookEventType string
+
+// V2 webhook event types.
+const (
+	WebhookIncidentTrigger       WebhookEventType = "incident.trigger"
+	WebhookIncidentAcknowledge   WebhookEventType = "incident.acknowledge"
+	WebhookIncidentUnacknowledge WebhookEventType = "incident.unacknowledge"
+	WebhookIncidentResolve       WebhookEventType = "incident.resolve"
+	WebhookIncidentAssign        WebhookEventType = "incident.assign"
+	WebhookIncidentEscalate      WebhookEventType = "incident.escalate"
+	WebhookIncidentDelegate      WebhookEventType = "incident.delegate"
+	WebhookIncidentAnnotate      WebhookEventType = "incident.annotate"
+)
+
+// Known reports whether t is one of the event types decoded by
+// DecodeWebhookMessages.
+func (t WebhookEventType) Known() bool {
+	switch t {
+	case WebhookIncidentTrigger, WebhookIncidentAcknowledge, WebhookIncidentUnacknowledge,
+		WebhookIncidentResolve, WebhookIncidentAssign, WebhookIncidentEscalate,
+		WebhookIncidentDelegate, WebhookIncidentAnnotate:
+		return true
+	}
+	return false
+}
+
+// WebhookMessage is a message of a V2 webhook.
+type WebhookMessage struct {
+	ID        string           `json:"id"`
+	Event     WebhookEventType `json:"event"`
+	CreatedOn string           `json:"created_on"`
+	// Incident and LogEntries are only set for known event types.
+	Incident   *Incident  `json:"incident"`
+	LogEntries []LogEntry `json:"log_entries"`
+	// Raw is the message as received, kept for event types this package does
+	// not know about.
+	Raw json.RawMessage `json:"-"`
+}
+
+// Agent returns who or what caused the message, as reported by its first log
+// entry.
+func (m WebhookMessage) Agent() *Agent {
+	if len(m.LogEntries) == 0 || m.LogEntries[0].Agent.ID == "" {
+		return nil
+	}
+	agent := m.LogEntries[0].Agent
+	return &agent
+}
+
+// DecodeWebhookMessages decodes the messages of a V2 webhook, sent as
+// {"messages": [...]}. Messages of unknown event types only have their ID,
+// Event, CreatedOn and Raw fields set.
+func DecodeWebhookMessages(r io.Reader) ([]WebhookMessage, error) {
+	var envelope struct {
+		Messages []json.RawMessage `json:"messages"`
+	}
+	if err := json.NewDecoder(r).Decode(&envelope); err != nil {
+		return nil, err
+	}
+	messages := make([]WebhookMessage, len(envelope.Messages))
+	for i, raw := range envelope.Messages {
+		m := &messages[i]
+		var header struct {
+			ID        string           `json:"id"`
+			Event     WebhookEventType `json:"event"`
+			CreatedOn string           `json:"created_on"`
+		}
+		if err := json.Unmarshal(raw, &header); err != nil {
+			return nil, err
+		}
+		m.ID, m.Event, m.CreatedOn = header.ID, header.Event, header.CreatedOn
+		if m.Event.Known() {
+			if err := json.Unmarshal(raw, m); err != nil {
+				return nil, fmt.Errorf("could not decode %s message %s: %v", m.Event, m.ID, err)
+			}
+		}
+		m.Raw = raw
+	}
+	return messages, nil
+}
+
 type Webhook struct {
 	APIObject
 }
@@ -57,4 +147,103 @@ func NewWebhookResponse(resp *http.Response) WebhookResponse {
 
 type ListWebhooksResponse struct {
 	APIListObject
+	Webhooks []Webhook `json:"webhooks"`
+}
+
+func (list ListWebhooksResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.Webhooks))
+	for i, r := range list.Webhooks {
+		resources[i] = r
+	}
+	return resources
+}
+
+// WebhookSignatureHeader is the header holding the signatures of a V3 webhook
+// delivery.
+const WebhookSignatureHeader = "X-PagerDuty-Signature"
+
+// Errors returned by WebhookVerifier.
+var (
+	ErrMissingWebhookSignature = errors.New("pagerduty: webhook signature is missing")
+	ErrInvalidWebhookSignature = errors.New("pagerduty: webhook signature is invalid")
+	ErrMissingWebhookSecret    = errors.New("pagerduty: webhook secret is missing")
+)
+
+// WebhookVerifier checks that V3 webhook deliveries were signed by PagerDuty
+// with the secret of a webhook subscription. Several secrets can be given, so
+// one can be rotated without rejecting deliveries.
+type WebhookVerifier struct {
+	secrets [][]byte
+}
+
+// NewWebhookVerifier returns a WebhookVerifier accepting signatures made with
+// any of secrets. It returns ErrMissingWebhookSecret when no secret is given
+// or one of them is empty.
+func NewWebhookVerifier(secrets ...string) (*WebhookVerifier, error) {
+	if len(secrets) == 0 {
+		return nil, ErrMissingWebhookSecret
+	}
+	v := &WebhookVerifier{}
+	for _, s := range secrets {
+		if s == "" {
+			return nil, ErrMissingWebhookSecret
+		}
+		v.secrets = append(v.secrets, []byte(s))
+	}
+	return v, nil
+}
+
+// Verify checks the value of the X-PagerDuty-Signature header against body.
+// The header holds comma-separated signatures of the form v1=<hex HMAC-SHA256>,
+// and one valid signature is enough. Verify returns ErrMissingWebhookSecret
+// when the verifier has no secret to check signatures with.
+func (v *WebhookVerifier) Verify(signatures string, body []byte) error {
+	if !v.hasSecret() {
+		return ErrMissingWebhookSecret
+	}
+	if signatures == "" {
+		return ErrMissingWebhookSignature
+	}
+	for _, sig := range strings.Split(signatures, ",") {
+		sig = strings.TrimSpace(sig)
+		if !strings.HasPrefix(sig, "v1=") {
+			continue
+		}
+		got, err := hex.DecodeString(strings.TrimPrefix(sig, "v1="))
+		if err != nil {
+			continue
+		}
+		for _, secret := range v.secrets {
+			if len(secret) == 0 {
+				continue
+			}
+			mac := hmac.New(sha256.New, secret)
+			mac.Write(body)
+			if hmac.Equal(got, mac.Sum(nil)) {
+				return nil
+			}
+		}
+	}
+	return ErrInvalidWebhookSignature
+}
+
+func (v *WebhookVerifier) hasSecret() bool {
+	for _, secret := range v.secrets {
+		if len(secret) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// VerifyRequest reads the body of r and verifies its signature. The body is
+// returned, and r.Body is replaced so it can be read again.
+func (v *WebhookVerifier) VerifyRequest(r *http.Request) ([]byte, error) {
+	body, err := ioutil.ReadAll(r.Body)
+	r.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return body, v.Verify(r.Header.Get(WebhookSignatureHeader), body)
 }
@@ -0,0 +1,311 @@
+package pagerduty
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// V3 webhook event types handled by WebhookHandler. V2 webhook messages are
+// reported with the matching V3 type.
+const (
+	IncidentTriggered      = "incident.triggered"
+	IncidentAcknowledged   = "incident.acknowledged"
+	IncidentUnacknowledged = "incident.unacknowledged"
+	IncidentResolved       = "incident.resolved"
+	IncidentReassigned     = "incident.reassigned"
+	IncidentEscalated      = "incident.escalated"
+	IncidentDelegated      = "incident.delegated"
+	IncidentAnnotated      = "incident.annotated"
+)
+
+// v3EventTypes maps V2 webhook event types to their V3 equivalent.
+var v3EventTypes = map[WebhookEventType]string{
+	WebhookIncidentTrigger:       IncidentTriggered,
+	WebhookIncidentAcknowledge:   IncidentAcknowledged,
+	WebhookIncidentUnacknowledge: IncidentUnacknowledged,
+	WebhookIncidentResolve:       IncidentResolved,
+	WebhookIncidentAssign:        IncidentReassigned,
+	WebhookIncidentEscalate:      IncidentEscalated,
+	WebhookIncidentDelegate:      IncidentDelegated,
+	WebhookIncidentAnnotate:      IncidentAnnotated,
+}
+
+// IncidentEvent is an incident event received through a V2 or V3 webhook.
+type IncidentEvent struct {
+	// ID identifies the event. Redeliveries of an event have the same ID.
+	ID string
+	// Type is the V3 event type, such as IncidentTriggered.
+	Type       string
+	OccurredAt string
+	Incident   Incident
+	// Agent is who or what caused the event, when known.
+	Agent *Agent
+	// Raw is the event or message as received.
+	Raw json.RawMessage
+}
+
+// IncidentEventHandler handles an incident event. Returning an error makes
+// the WebhookHandler answer with a server error, so PagerDuty delivers the
+// event again.
+type IncidentEventHandler func(ctx context.Context, e IncidentEvent) error
+
+// WebhookMaxBodySize is the largest webhook delivery WebhookHandler accepts,
+// in bytes. Larger deliveries are answered with 413.
+const WebhookMaxBodySize = 1 << 20
+
+// WebhookHandlerOptionFunc configures a WebhookHandler.
+type WebhookHandlerOptionFunc func(*WebhookHandler)
+
+// WithWebhookSecrets makes the handler reject deliveries not signed with one
+// of secrets. V2 webhooks are not signed, so they are rejected too. When no
+// secret is given or one of them is empty, every delivery is rejected.
+func WithWebhookSecrets(secrets ...string) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		v, err := NewWebhookVerifier(secrets...)
+		if err != nil {
+			v = &WebhookVerifier{}
+		}
+		h.verifier = v
+	}
+}
+
+// WithDedupWindow sets how long the handler remembers the IDs of handled
+// events, to ignore their redeliveries. It defaults to one hour.
+func WithDedupWindow(d time.Duration) WebhookHandlerOptionFunc {
+	return func(h *WebhookHandler) {
+		h.dedupWindow = d
+	}
+}
+
+// WebhookHandler is an http.Handler receiving PagerDuty webhooks. It verifies
+// their signature, decodes V2 and V3 payloads, and calls the handlers
+// registered for the type of each event:
+//
+//	h := pagerduty.NewWebhookHandler(pagerduty.WithWebhookSecrets(secret))
+//	h.OnIncidentTriggered(func(ctx context.Context, e pagerduty.IncidentEvent) error {
+//		return page(e.Incident)
+//	})
+//	http.Handle("/pagerduty", h)
+//
+// Events without a registered handler are acknowledged and ignored. Events
+// already handled successfully are not handled again when redelivered.
+type WebhookHandler struct {
+	verifier    *WebhookVerifier
+	dedupWindow time.Duration
+
+	mu       sync.Mutex
+	handlers map[string][]IncidentEventHandler
+	seen     map[string]time.Time
+}
+
+// NewWebhookHandler returns a WebhookHandler without any event handler.
+func NewWebhookHandler(opts ...WebhookHandlerOptionFunc) *WebhookHandler {
+	h := &WebhookHandler{
+		dedupWindow: time.Hour,
+		handlers:    make(map[string][]IncidentEventHandler),
+		seen:        make(map[string]time.Time),
+	}
+	for _, opt := range opts {
+		opt(h)
+	}
+	return h
+}
+
+// On registers fn for events of the given V3 type.
+func (h *WebhookHandler) On(eventType string, fn IncidentEventHandler) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.handlers[eventType] = append(h.handlers[eventType], fn)
+}
+
+// OnIncidentTriggered registers fn for incident.triggered events.
+func (h *WebhookHandler) OnIncidentTriggered(fn IncidentEventHandler) {
+	h.On(IncidentTriggered, fn)
+}
+
+// OnIncidentAcknowledged registers fn for incident.acknowledged events.
+func (h *WebhookHandler) OnIncidentAcknowledged(fn IncidentEventHandler) {
+	h.On(IncidentAcknowledged, fn)
+}
+
+// OnIncidentUnacknowledged registers fn for incident.unacknowledged events.
+func (h *WebhookHandler) OnIncidentUnacknowledged(fn IncidentEventHandler) {
+	h.On(IncidentUnacknowledged, fn)
+}
+
+// OnIncidentResolved registers fn for incident.resolved events.
+func (h *WebhookHandler) OnIncidentResolved(fn IncidentEventHandler) {
+	h.On(IncidentResolved, fn)
+}
+
+// OnIncidentReassigned registers fn for incident.reassigned events.
+func (h *WebhookHandler) OnIncidentReassigned(fn IncidentEventHandler) {
+	h.On(IncidentReassigned, fn)
+}
+
+// OnIncidentEscalated registers fn for incident.escalated events.
+func (h *WebhookHandler) OnIncidentEscalated(fn IncidentEventHandler) {
+	h.On(IncidentEscalated, fn)
+}
+
+// OnIncidentDelegated registers fn for incident.delegated events.
+func (h *WebhookHandler) OnIncidentDelegated(fn IncidentEventHandler) {
+	h.On(IncidentDelegated, fn)
+}
+
+// OnIncidentAnnotated registers fn for incident.annotated events.
+func (h *WebhookHandler) OnIncidentAnnotated(fn IncidentEventHandler) {
+	h.On(IncidentAnnotated, fn)
+}
+
+// ServeHTTP handles a webhook delivery. It answers 405 to other methods than
+// POST, 413 to deliveries larger than WebhookMaxBodySize, 401 to deliveries
+// with an invalid signature, 400 to payloads it cannot decode and 500 when an
+// event handler fails.
+func (h *WebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, WebhookMaxBodySize))
+	if err != nil {
+		// MaxBytesReader fails once the limit has been read entirely.
+		if len(body) >= WebhookMaxBodySize {
+			http.Error(w, "request body too large", http.StatusRequestEntityTooLarge)
+			return
+		}
+		http.Error(w, "could not read body", http.StatusBadRequest)
+		return
+	}
+	if h.verifier != nil {
+		if err := h.verifier.Verify(r.Header.Get(WebhookSignatureHeader), body); err != nil {
+			http.Error(w, err.Error(), http.StatusUnauthorized)
+			return
+		}
+	}
+	events, err := decodeIncidentEvents(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	for _, e := range events {
+		if err := h.dispatch(r.Context(), e); err != nil {
+			http.Error(w, "could not handle event "+e.ID, http.StatusInternalServerError)
+			return
+		}
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// dispatch calls the handlers of e, unless it was already handled or is being
+// handled by a concurrent delivery. The ID of e is reserved before calling the
+// handlers, and released if one of them fails so the event can be delivered
+// again.
+func (h *WebhookHandler) dispatch(ctx context.Context, e IncidentEvent) error {
+	h.mu.Lock()
+	now := time.Now()
+	for id, expiry := range h.seen {
+		if now.After(expiry) {
+			delete(h.seen, id)
+		}
+	}
+	if _, seen := h.seen[e.ID]; seen {
+		h.mu.Unlock()
+		return nil
+	}
+	if e.ID != "" {
+		h.seen[e.ID] = now.Add(h.dedupWindow)
+	}
+	handlers := h.handlers[e.Type]
+	h.mu.Unlock()
+	for _, fn := range handlers {
+		if err := fn(ctx, e); err != nil {
+			if e.ID != "" {
+				h.mu.Lock()
+				delete(h.seen, e.ID)
+				h.mu.Unlock()
+			}
+			return err
+		}
+	}
+	return nil
+}
+
+// decodeIncidentEvents decodes the events of a V3 webhook, sent as
+// {"event": {...}}, or the messages of a V2 webhook, sent as
+// {"messages": [...]}.
+func decodeIncidentEvents(body []byte) ([]IncidentEvent, error) {
+	var envelope struct {
+		Event    *json.RawMessage `json:"event"`
+		Messages *json.RawMessage `json:"messages"`
+	}
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		return nil, err
+	}
+	if envelope.Event != nil {
+		e, err := decodeV3Event(*envelope.Event)
+		if err != nil {
+			return nil, err
+		}
+		return []IncidentEvent{e}, nil
+	}
+	if envelope.Messages == nil {
+		return nil, errors.New("payload has neither an event nor messages")
+	}
+	messages, err := DecodeWebhookMessages(bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	events := make([]IncidentEvent, len(messages))
+	for i, m := range messages {
+		e := IncidentEvent{ID: m.ID, Type: string(m.Event), OccurredAt: m.CreatedOn, Agent: m.Agent(), Raw: m.Raw}
+		if t, ok := v3EventTypes[m.Event]; ok {
+			e.Type = t
+		}
+		if m.Incident != nil {
+			e.Incident = *m.Incident
+		}
+		events[i] = e
+	}
+	return events, nil
+}
+
+func decodeV3Event(raw json.RawMessage) (IncidentEvent, error) {
+	var v3 struct {
+		ID         string          `json:"id"`
+		EventType  string          `json:"event_type"`
+		OccurredAt string          `json:"occurred_at"`
+		Agent      *Agent          `json:"agent"`
+		Data       json.RawMessage `json:"data"`
+	}
+	if err := json.Unmarshal(raw, &v3); err != nil {
+		return IncidentEvent{}, err
+	}
+	e := IncidentEvent{ID: v3.ID, Type: v3.EventType, OccurredAt: v3.OccurredAt, Agent: v3.Agent, Raw: raw}
+	if strings.HasPrefix(v3.EventType, "incident.") && len(v3.Data) > 0 {
+		// V3 incidents name a few fields differently from the REST API.
+		var data struct {
+			Number uint   `json:"number"`
+			Title  string `json:"title"`
+		}
+		if err := json.Unmarshal(v3.Data, &e.Incident); err != nil {
+			return IncidentEvent{}, err
+		}
+		json.Unmarshal(v3.Data, &data)
+		if e.Incident.IncidentNumber == 0 {
+			e.Incident.IncidentNumber = data.Number
+		}
+		if e.Incident.Summary == "" {
+			e.Incident.Summary = data.Title
+		}
+	}
+	return e, nil
+}
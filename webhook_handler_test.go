@@ -0,0 +1,170 @@
+package pagerduty
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+const webhookV3Body = `{
+  "event": {
+    "id": "5ac64822-4adc-4fda-ade0-410becf0de4f",
+    "event_type": "incident.triggered",
+    "resource_type": "incident",
+    "occurred_at": "2020-10-02T18:45:22.169Z",
+    "agent": {"id": "PLH1HKV", "type": "user_reference", "summary": "Tenex Engineer"},
+    "data": {
+      "id": "PGR0VU2",
+      "type": "incident",
+      "number": 2,
+      "status": "triggered",
+      "title": "A little bump in the road",
+      "service": {"id": "PF9KMXH", "type": "service_reference", "summary": "API Service"},
+      "urgency": "high"
+    }
+  }
+}`
+
+func postWebhook(h http.Handler, body, signature string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/pagerduty", strings.NewReader(body))
+	if signature != "" {
+		req.Header.Set(WebhookSignatureHeader, signature)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func signWebhook(secret, body string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return "v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestWebhookHandlerDispatchesV3Events(t *testing.T) {
+	h := NewWebhookHandler(WithWebhookSecrets("secret"))
+	var events []IncidentEvent
+	h.OnIncidentTriggered(func(ctx context.Context, e IncidentEvent) error {
+		events = append(events, e)
+		return nil
+	})
+
+	if rec := postWebhook(h, webhookV3Body, signWebhook("forged", webhookV3Body)); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected a forged delivery to be rejected, got %d", rec.Code)
+	}
+	for i := 0; i < 2; i++ {
+		if rec := postWebhook(h, webhookV3Body, signWebhook("secret", webhookV3Body)); rec.Code != http.StatusOK {
+			t.Errorf("delivery %d: unexpected status %d", i, rec.Code)
+		}
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected the redelivered event to be handled once, got %d", len(events))
+	}
+	e := events[0]
+	if e.Type != IncidentTriggered || e.Incident.ID != "PGR0VU2" || e.Incident.IncidentNumber != 2 ||
+		e.Incident.Summary != "A little bump in the road" || e.Agent == nil || e.Agent.ID != "PLH1HKV" {
+		t.Errorf("unexpected event %+v", e)
+	}
+}
+
+func TestWebhookHandlerRejectsEmptySecret(t *testing.T) {
+	h := NewWebhookHandler(WithWebhookSecrets(""))
+	h.OnIncidentTriggered(func(ctx context.Context, e IncidentEvent) error {
+		t.Error("unexpected event handled")
+		return nil
+	})
+	if rec := postWebhook(h, webhookV3Body, signWebhook("", webhookV3Body)); rec.Code != http.StatusUnauthorized {
+		t.Errorf("expected a delivery signed with an empty secret to be rejected, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandlerV2Messages(t *testing.T) {
+	h := NewWebhookHandler()
+	var handled []string
+	h.OnIncidentAcknowledged(func(ctx context.Context, e IncidentEvent) error {
+		handled = append(handled, e.Incident.ID)
+		return nil
+	})
+	if rec := postWebhook(h, webhookV2Body, ""); rec.Code != http.StatusOK {
+		t.Errorf("unexpected status %d", rec.Code)
+	}
+	if len(handled) != 1 || handled[0] != "PIJ90N7" {
+		t.Errorf("unexpected handled incidents %v", handled)
+	}
+}
+
+func TestWebhookHandlerStatuses(t *testing.T) {
+	h := NewWebhookHandler()
+	fail := true
+	h.OnIncidentTriggered(func(ctx context.Context, e IncidentEvent) error {
+		if fail {
+			return errors.New("database unavailable")
+		}
+		return nil
+	})
+
+	if rec := postWebhook(h, webhookV3Body, ""); rec.Code != http.StatusInternalServerError {
+		t.Errorf("expected a failed handler to return 500, got %d", rec.Code)
+	}
+	fail = false
+	if rec := postWebhook(h, webhookV3Body, ""); rec.Code != http.StatusOK {
+		t.Errorf("expected the redelivery to succeed, got %d", rec.Code)
+	}
+	if rec := postWebhook(h, `{"unexpected": true}`, ""); rec.Code != http.StatusBadRequest {
+		t.Errorf("expected an unknown payload to return 400, got %d", rec.Code)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/pagerduty", nil))
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("expected GET to return 405, got %d", rec.Code)
+	}
+}
+
+func TestWebhookHandlerConcurrentRedelivery(t *testing.T) {
+	h := NewWebhookHandler()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	var mu sync.Mutex
+	calls := 0
+	h.OnIncidentTriggered(func(ctx context.Context, e IncidentEvent) error {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		close(started)
+		<-release
+		return nil
+	})
+
+	done := make(chan int)
+	go func() {
+		done <- postWebhook(h, webhookV3Body, "").Code
+	}()
+	<-started
+	if rec := postWebhook(h, webhookV3Body, ""); rec.Code != http.StatusOK {
+		t.Errorf("unexpected status for the redelivery %d", rec.Code)
+	}
+	close(release)
+	if code := <-done; code != http.StatusOK {
+		t.Errorf("unexpected status for the first delivery %d", code)
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	if calls != 1 {
+		t.Errorf("expected the event to be handled once, got %d", calls)
+	}
+}
+
+func TestWebhookHandlerBodyTooLarge(t *testing.T) {
+	h := NewWebhookHandler()
+	body := `{"event": {"id": "` + strings.Repeat("a", WebhookMaxBodySize) + `"}}`
+	if rec := postWebhook(h, body, ""); rec.Code != http.StatusRequestEntityTooLarge {
+		t.Errorf("expected an oversized delivery to return 413, got %d", rec.Code)
+	}
+}
@@ -0,0 +1,250 @@
+package pagerduty
+
+import (
+	"context"
+	"net/http"
+)
+
+// Filter types of a webhook subscription.
+const (
+	WebhookFilterAccount = "account_reference"
+	WebhookFilterService = "service_reference"
+	WebhookFilterTeam    = "team_reference"
+)
+
+// WebhookSubscription is a V3 webhook subscription: PagerDuty sends the events
+// it subscribes to, for the service, team or account of its filter, to the URL
+// of its delivery method.
+type WebhookSubscription struct {
+	APIObject
+	Description    string                    `json:"description,omitempty"`
+	DeliveryMethod WebhookDeliveryMethod     `json:"delivery_method"`
+	Events         []string                  `json:"events"`
+	Filter         WebhookSubscriptionFilter `json:"filter"`
+	// Active is whether PagerDuty sends events to the subscription. It must
+	// be set when creating a subscription, which is otherwise disabled.
+	Active bool `json:"active"`
+}
+
+// WebhookDeliveryMethod is where and how the events of a webhook subscription
+// are sent.
+type WebhookDeliveryMethod struct {
+	ID            string                `json:"id,omitempty"`
+	Type          string                `json:"type"`
+	URL           string                `json:"url"`
+	CustomHeaders []WebhookCustomHeader `json:"custom_headers,omitempty"`
+	// Secret is the signing secret of the subscription, used to verify the
+	// X-PagerDuty-Signature header of deliveries. It is only returned when
+	// the subscription is created.
+	Secret string `json:"secret,omitempty"`
+	// TemporarilyDisabled is set by PagerDuty after repeated delivery
+	// failures. EnableWebhookSubscription clears it.
+	TemporarilyDisabled bool `json:"temporarily_disabled,omitempty"`
+}
+
+// WebhookCustomHeader is a header added to every delivery.
+type WebhookCustomHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// WebhookSubscriptionFilter selects the objects a webhook subscription
+// receives events for.
+type WebhookSubscriptionFilter struct {
+	// Type is one of WebhookFilterAccount, WebhookFilterService or
+	// WebhookFilterTeam.
+	Type string `json:"type"`
+	// ID is the ID of the service or team. It is empty for an account filter.
+	ID string `json:"id,omitempty"`
+}
+
+type WebhookSubscriptionResponse struct {
+	APIResponse
+}
+
+func (r WebhookSubscriptionResponse) GetResource() (Resource, error) {
+	var dest WebhookSubscription
+	err := r.getResourceFromResponse(&dest)
+	return dest, err
+}
+
+func NewWebhookSubscriptionResponse(resp *http.Response) WebhookSubscriptionResponse {
+	return WebhookSubscriptionResponse{APIResponse{raw: resp, apiType: WebhookSubscriptionResourceType}}
+}
+
+// ListWebhookSubscriptionsResponse is the data structure returned from calling
+// the ListWebhookSubscriptions API endpoint.
+type ListWebhookSubscriptionsResponse struct {
+	APIListObject
+	WebhookSubscriptions []WebhookSubscription `json:"webhook_subscriptions"`
+}
+
+func (list ListWebhookSubscriptionsResponse) GetResources() []Resource {
+	resources := make([]Resource, len(list.WebhookSubscriptions))
+	for i, r := range list.WebhookSubscriptions {
+		resources[i] = r
+	}
+	return resources
+}
+
+// ListWebhookSubscriptionsOptions is the data structure used when calling the
+// ListWebhookSubscriptions API endpoint.
+type ListWebhookSubscriptionsOptions struct {
+	FilterType string `url:"filter_type,omitempty"`
+	FilterID   string `url:"filter_id,omitempty"`
+}
+
+// ListWebhookSubscriptions lists the webhook subscriptions, optionally
+// filtered with WithOptions(ListWebhookSubscriptionsOptions{...}).
+func (c *Client) ListWebhookSubscriptions(opts ...ResourceRequestOptionFunc) (*ListWebhookSubscriptionsResponse, error) {
+	return c.ListWebhookSubscriptionsWithContext(context.Background(), opts...)
+}
+
+// ListWebhookSubscriptionsWithContext is like ListWebhookSubscriptions but
+// takes a context.
+func (c *Client) ListWebhookSubscriptionsWithContext(ctx context.Context, opts ...ResourceRequestOptionFunc) (*ListWebhookSubscriptionsResponse, error) {
+	resp, err := c.ListResourcesWithContext(ctx, WebhookSubscriptionResourceType, opts...)
+	if err != nil {
+		return nil, err
+	}
+	var result ListWebhookSubscriptionsResponse
+	return &result, deserialize(resp, &result)
+}
+
+// GetWebhookSubscription gets details about a webhook subscription.
+func (c *Client) GetWebhookSubscription(id string) (*WebhookSubscription, error) {
+	return c.GetWebhookSubscriptionWithContext(context.Background(), id)
+}
+
+// GetWebhookSubscriptionWithContext is like GetWebhookSubscription but takes a
+// context.
+func (c *Client) GetWebhookSubscriptionWithContext(ctx context.Context, id string) (*WebhookSubscription, error) {
+	res, err := c.GetResourceWithContext(ctx, WebhookSubscriptionResourceType, id)
+	if err != nil {
+		return nil, err
+	}
+	obj := res.(WebhookSubscription)
+	return &obj, nil
+}
+
+// CreateWebhookSubscription creates a webhook subscription. The returned
+// subscription holds the signing secret of its deliveries.
+func (c *Client) CreateWebhookSubscription(s WebhookSubscription) (*WebhookSubscription, error) {
+	return c.CreateWebhookSubscriptionWithContext(context.Background(), s)
+}
+
+// CreateWebhookSubscriptionWithContext is like CreateWebhookSubscription but
+// takes a context.
+func (c *Client) CreateWebhookSubscriptionWithContext(ctx context.Context, s WebhookSubscription) (*WebhookSubscription, error) {
+	res, err := c.CreateResourceWithContext(ctx, s.withDefaults())
+	if err != nil {
+		return nil, err
+	}
+	obj := res.(WebhookSubscription)
+	return &obj, nil
+}
+
+// UpdateWebhookSubscriptionOptions are the changes made to a webhook
+// subscription by UpdateWebhookSubscription. Fields left nil or empty are not
+// changed.
+type UpdateWebhookSubscriptionOptions struct {
+	Description *string                    `json:"description,omitempty"`
+	Events      []string                   `json:"events,omitempty"`
+	Filter      *WebhookSubscriptionFilter `json:"filter,omitempty"`
+	Active      *bool                      `json:"active,omitempty"`
+}
+
+// UpdateWebhookSubscription updates an existing webhook subscription.
+func (c *Client) UpdateWebhookSubscription(id string, o UpdateWebhookSubscriptionOptions) (*WebhookSubscription, error) {
+	return c.UpdateWebhookSubscriptionWithContext(context.Background(), id, o)
+}
+
+// UpdateWebhookSubscriptionWithContext is like UpdateWebhookSubscription but
+// takes a context.
+func (c *Client) UpdateWebhookSubscriptionWithContext(ctx context.Context, id string, o UpdateWebhookSubscriptionOptions) (*WebhookSubscription, error) {
+	data := map[string]interface{}{
+		"webhook_subscription": struct {
+			Type APIResourceType `json:"type"`
+			UpdateWebhookSubscriptionOptions
+		}{WebhookSubscriptionResourceType, o},
+	}
+	resp, err := c.put(ctx, "/webhook_subscriptions/"+id, data)
+	return getWebhookSubscriptionFromResponse(resp, err)
+}
+
+// DeleteWebhookSubscription deletes a webhook subscription.
+func (c *Client) DeleteWebhookSubscription(id string) error {
+	return c.DeleteWebhookSubscriptionWithContext(context.Background(), id)
+}
+
+// DeleteWebhookSubscriptionWithContext is like DeleteWebhookSubscription but
+// takes a context.
+func (c *Client) DeleteWebhookSubscriptionWithContext(ctx context.Context, id string) error {
+	return c.DeleteResourceWithContext(ctx, WebhookSubscriptionResourceType, id)
+}
+
+// EnableWebhookSubscription re-enables a webhook subscription, including one
+// temporarily disabled by PagerDuty after delivery failures.
+func (c *Client) EnableWebhookSubscription(id string) (*WebhookSubscription, error) {
+	return c.EnableWebhookSubscriptionWithContext(context.Background(), id)
+}
+
+// EnableWebhookSubscriptionWithContext is like EnableWebhookSubscription but
+// takes a context.
+func (c *Client) EnableWebhookSubscriptionWithContext(ctx context.Context, id string) (*WebhookSubscription, error) {
+	resp, err := c.post(ctx, "/webhook_subscriptions/"+id+"/enable", nil)
+	return getWebhookSubscriptionFromResponse(resp, err)
+}
+
+// DisableWebhookSubscription stops the deliveries of a webhook subscription.
+func (c *Client) DisableWebhookSubscription(id string) (*WebhookSubscription, error) {
+	return c.DisableWebhookSubscriptionWithContext(context.Background(), id)
+}
+
+// DisableWebhookSubscriptionWithContext is like DisableWebhookSubscription but
+// takes a context.
+func (c *Client) DisableWebhookSubscriptionWithContext(ctx context.Context, id string) (*WebhookSubscription, error) {
+	data := map[string]map[string]bool{"webhook_subscription": {"active": false}}
+	resp, err := c.put(ctx, "/webhook_subscriptions/"+id, data)
+	return getWebhookSubscriptionFromResponse(resp, err)
+}
+
+// TestWebhookSubscription makes PagerDuty send a test event, of type
+// pagey.ping, to a webhook subscription.
+func (c *Client) TestWebhookSubscription(id string) error {
+	return c.TestWebhookSubscriptionWithContext(context.Background(), id)
+}
+
+// TestWebhookSubscriptionWithContext is like TestWebhookSubscription but takes
+// a context.
+func (c *Client) TestWebhookSubscriptionWithContext(ctx context.Context, id string) error {
+	resp, err := c.post(ctx, "/webhook_subscriptions/"+id+"/ping", nil)
+	if err != nil {
+		return err
+	}
+	return resp.Body.Close()
+}
+
+// withDefaults sets the type of s and of its delivery method when missing.
+func (s WebhookSubscription) withDefaults() WebhookSubscription {
+	if s.Type == "" {
+		s.Type = WebhookSubscriptionResourceType
+	}
+	if s.DeliveryMethod.Type == "" {
+		s.DeliveryMethod.Type = "http_delivery_method"
+	}
+	return s
+}
+
+func getWebhookSubscriptionFromResponse(resp *http.Response, err error) (*WebhookSubscription, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	res, err := NewWebhookSubscriptionResponse(resp).GetResource()
+	if err != nil {
+		return nil, err
+	}
+	obj := res.(WebhookSubscription)
+	return &obj, nil
+}
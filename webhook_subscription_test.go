@@ -0,0 +1,79 @@
+package pagerduty
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestWebhookSubscriptions(t *testing.T) {
+	var requests []string
+	var created map[string]WebhookSubscription
+	var updated map[string]map[string]json.RawMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.RequestURI())
+		switch r.URL.Path {
+		case "/webhook_subscriptions":
+			if r.Method == http.MethodPost {
+				json.NewDecoder(r.Body).Decode(&created)
+				w.WriteHeader(http.StatusCreated)
+				w.Write([]byte(`{"webhook_subscription": {"id": "PWS1", "type": "webhook_subscription", "active": true, "delivery_method": {"type": "http_delivery_method", "url": "https://example.com/hooks", "secret": "s3cr3t"}}}`))
+				return
+			}
+			w.Write([]byte(`{"webhook_subscriptions": [{"id": "PWS1", "type": "webhook_subscription"}], "more": false}`))
+		case "/webhook_subscriptions/PWS1":
+			json.NewDecoder(r.Body).Decode(&updated)
+			w.Write([]byte(`{"webhook_subscription": {"id": "PWS1", "type": "webhook_subscription", "active": true, "description": "Checkout"}}`))
+		case "/webhook_subscriptions/PWS1/enable":
+			w.Write([]byte(`{"webhook_subscription": {"id": "PWS1", "type": "webhook_subscription", "active": true}}`))
+		case "/webhook_subscriptions/PWS1/ping":
+			w.WriteHeader(http.StatusAccepted)
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL)
+		}
+	}))
+	defer server.Close()
+	client := NewClient("123", WithCustomHost(server.URL))
+
+	sub, err := client.CreateWebhookSubscription(WebhookSubscription{
+		DeliveryMethod: WebhookDeliveryMethod{URL: "https://example.com/hooks"},
+		Events:         []string{"incident.triggered", "incident.resolved"},
+		Filter:         WebhookSubscriptionFilter{Type: WebhookFilterService, ID: "PSVC1"},
+		Active:         true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sub.ID != "PWS1" || sub.DeliveryMethod.Secret != "s3cr3t" {
+		t.Errorf("unexpected subscription %+v", sub)
+	}
+	sent := created["webhook_subscription"]
+	if sent.Type != WebhookSubscriptionResourceType || sent.DeliveryMethod.Type != "http_delivery_method" || sent.Filter.ID != "PSVC1" {
+		t.Errorf("unexpected subscription sent %+v", sent)
+	}
+
+	list, err := client.ListWebhookSubscriptions(WithOptions(ListWebhookSubscriptionsOptions{FilterType: WebhookFilterService, FilterID: "PSVC1"}))
+	if err != nil || len(list.WebhookSubscriptions) != 1 {
+		t.Errorf("unexpected list %+v: %v", list, err)
+	}
+	description := "Checkout"
+	if sub, err := client.UpdateWebhookSubscription("PWS1", UpdateWebhookSubscriptionOptions{Description: &description}); err != nil || sub.Description != "Checkout" {
+		t.Errorf("unexpected updated subscription %+v: %v", sub, err)
+	}
+	if _, ok := updated["webhook_subscription"]["active"]; ok {
+		t.Errorf("expected a partial update to leave active out, got %v", updated)
+	}
+	if _, ok := updated["webhook_subscription"]["events"]; ok {
+		t.Errorf("expected a partial update to leave events out, got %v", updated)
+	}
+	if sub, err := client.EnableWebhookSubscription("PWS1"); err != nil || !sub.Active {
+		t.Errorf("unexpected enabled subscription %+v: %v", sub, err)
+	}
+	if err := client.TestWebhookSubscription("PWS1"); err != nil {
+		t.Error(err)
+	}
+	if requests[1] != "GET /webhook_subscriptions?filter_id=PSVC1&filter_type=service_reference" {
+		t.Errorf("unexpected list request %s", requests[1])
+	}
+}
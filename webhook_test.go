@@ -0,0 +1,123 @@
+package pagerduty
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const webhookV2Body = `{
+  "messages": [
+    {
+      "id": "bb8b8fe0-e8d5-11e2-9c1e-22000afd16cf",
+      "event": "incident.acknowledge",
+      "created_on": "2013-07-09T20:25:44Z",
+      "incident": {
+        "id": "PIJ90N7",
+        "type": "incident",
+        "summary": "[#1234] The server is on fire.",
+        "incident_number": 1234,
+        "status": "acknowledged",
+        "service": {"id": "PIJ90N7", "type": "service_reference", "summary": "My Mail Service"},
+        "assignments": [{"at": "2013-07-09T20:25:44Z", "assignee": {"id": "P8B9WR8", "type": "user_reference"}}]
+      },
+      "log_entries": [
+        {
+          "id": "R2XGXEI3W0FHMAU5CCL2I4S1Q4",
+          "type": "acknowledge_log_entry",
+          "agent": {"id": "P8B9WR8", "type": "user_reference", "summary": "Earline Greenholt"},
+          "channel": {"type": "website"}
+        }
+      ]
+    },
+    {
+      "id": "8a1d6420-e8d5-11e2-9c1e-22000afd16cf",
+      "event": "incident.custom_field_update",
+      "created_on": "2013-07-09T20:25:45Z",
+      "incident": "not an object"
+    }
+  ]
+}`
+
+func TestDecodeWebhookMessages(t *testing.T) {
+	messages, err := DecodeWebhookMessages(strings.NewReader(webhookV2Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(messages) != 2 {
+		t.Fatalf("expected 2 messages, got %d", len(messages))
+	}
+
+	ack := messages[0]
+	if ack.Event != WebhookIncidentAcknowledge || ack.Incident == nil {
+		t.Fatalf("unexpected message %+v", ack)
+	}
+	if ack.Incident.IncidentNumber != 1234 || ack.Incident.Service.Summary != "My Mail Service" ||
+		len(ack.Incident.Assignments) != 1 {
+		t.Errorf("unexpected incident %+v", ack.Incident)
+	}
+	if agent := ack.Agent(); agent == nil || agent.Summary != "Earline Greenholt" {
+		t.Errorf("unexpected agent %+v", agent)
+	}
+
+	unknown := messages[1]
+	if unknown.Event.Known() || unknown.Incident != nil || !strings.Contains(string(unknown.Raw), "not an object") {
+		t.Errorf("unknown message was not preserved: %+v", unknown)
+	}
+}
+
+func TestWebhookVerifier(t *testing.T) {
+	body := []byte(`{"event": {"id": "5ac64822-4adc-4fda-ade0-410becf0de4f"}}`)
+	sign := func(secret string) string {
+		mac := hmac.New(sha256.New, []byte(secret))
+		mac.Write(body)
+		return "v1=" + hex.EncodeToString(mac.Sum(nil))
+	}
+	v, err := NewWebhookVerifier("old-secret", "new-secret")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if err := v.Verify(sign("other")+","+sign("new-secret"), body); err != nil {
+		t.Errorf("expected a valid signature, got %v", err)
+	}
+	if err := v.Verify(sign("other"), body); err != ErrInvalidWebhookSignature {
+		t.Errorf("expected ErrInvalidWebhookSignature, got %v", err)
+	}
+	if err := v.Verify(sign("old-secret"), []byte(`{}`)); err != ErrInvalidWebhookSignature {
+		t.Errorf("expected a tampered body to be rejected, got %v", err)
+	}
+
+	req := httptest.NewRequest(http.MethodPost, "/hooks", bytes.NewReader(body))
+	if _, err := v.VerifyRequest(req); err != ErrMissingWebhookSignature {
+		t.Errorf("expected ErrMissingWebhookSignature, got %v", err)
+	}
+	req = httptest.NewRequest(http.MethodPost, "/hooks", bytes.NewReader(body))
+	req.Header.Set(WebhookSignatureHeader, sign("old-secret"))
+	if got, err := v.VerifyRequest(req); err != nil || string(got) != string(body) {
+		t.Errorf("unexpected result %q: %v", got, err)
+	}
+}
+
+func TestWebhookVerifierRequiresSecret(t *testing.T) {
+	for _, secrets := range [][]string{nil, {""}, {"secret", ""}} {
+		if _, err := NewWebhookVerifier(secrets...); err != ErrMissingWebhookSecret {
+			t.Errorf("%q: expected ErrMissingWebhookSecret, got %v", secrets, err)
+		}
+	}
+
+	body := []byte(`{}`)
+	mac := hmac.New(sha256.New, nil)
+	mac.Write(body)
+	signature := "v1=" + hex.EncodeToString(mac.Sum(nil))
+	for _, v := range []*WebhookVerifier{{}, {secrets: [][]byte{{}}}} {
+		if err := v.Verify(signature, body); err != ErrMissingWebhookSecret {
+			t.Errorf("expected a verifier without secret to fail, got %v", err)
+		}
+	}
+}